@@ -0,0 +1,367 @@
+package app
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/codegangsta/cli"
+
+	"github.com/cloudfoundry-incubator/candiedyaml"
+	"github.com/cloudfoundry-incubator/spiff/compare"
+	"github.com/cloudfoundry-incubator/spiff/flow"
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+// Run runs the spiff command line application with the command line
+// arguments.
+func Run(args []string) error {
+	app := NewApp()
+
+	return app.Run(escapeStdin(app.Commands, args))
+}
+
+// NewApp returns the spiff command line application. Run it with Run, which
+// takes care of arguments reading from stdin.
+func NewApp() *cli.App {
+	app := cli.NewApp()
+	app.Name = "spiff"
+	app.Usage = "BOSH deployment manifest toolkit"
+	app.Version = "1.0.8"
+
+	app.Commands = []cli.Command{
+		{
+			Name:      "merge",
+			ShortName: "m",
+			Usage:     "merge stub files into a manifest template",
+			Flags: []cli.Flag{
+				cli.StringSliceFlag{
+					Name:  "var",
+					Value: &cli.StringSlice{},
+					Usage: "variable available to references as vars.<key>, given as key=value",
+				},
+				cli.StringSliceFlag{
+					Name:  "vars-file",
+					Value: &cli.StringSlice{},
+					Usage: "YAML file with a map of variables",
+				},
+				cli.BoolFlag{
+					Name:  "enable-exec",
+					Usage: "allow the templates to run commands with exec()",
+				},
+				cli.BoolFlag{
+					Name:  "split",
+					Usage: "merge each document of the template separately, printing them as a stream",
+				},
+			},
+			Action: func(c *cli.Context) {
+				if len(c.Args()) < 1 {
+					cli.ShowCommandHelp(c, "merge")
+					os.Exit(1)
+				}
+
+				args := unescapeStdin(c.Args())
+
+				checkStdin(append(c.StringSlice("vars-file"), args...))
+
+				env := flow.Environment{
+					Variables:  readVariables(c.StringSlice("vars-file"), c.StringSlice("var")),
+					EnableExec: c.Bool("enable-exec"),
+				}
+
+				merge(args[0], args[1:], env, c.Bool("split"))
+			},
+		},
+		{
+			Name:      "diff",
+			ShortName: "d",
+			Usage:     "structurally compare two YAML files",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "separator",
+					Usage: "separator to print between diffs",
+				},
+			},
+			Action: func(c *cli.Context) {
+				if len(c.Args()) < 2 {
+					cli.ShowCommandHelp(c, "diff")
+					os.Exit(1)
+				}
+
+				args := unescapeStdin(c.Args())
+
+				diff(args[0], args[1], c.String("separator"))
+			},
+		},
+	}
+
+	return app
+}
+
+func merge(templateFilePath string, stubFilePaths []string, env flow.Environment, split bool) {
+	templateFile, err := readFile(templateFilePath)
+	if err != nil {
+		log.Fatalln(fmt.Sprintf("error reading template [%s]:", path.Clean(templateFilePath)), err)
+	}
+
+	var templates []yaml.Node
+
+	if split {
+		templates, err = yaml.ParseMulti(sourceName(templateFilePath), templateFile)
+	} else {
+		var templateYAML yaml.Node
+		templateYAML, err = yaml.Parse(sourceName(templateFilePath), templateFile)
+		templates = []yaml.Node{templateYAML}
+	}
+
+	if _, ok := err.(yaml.MultipleDocumentsError); ok {
+		log.Fatalln(fmt.Sprintf("error parsing template [%s]:", path.Clean(templateFilePath)), err, "(use --split to merge each of them)")
+	}
+
+	if err != nil {
+		log.Fatalln(fmt.Sprintf("error parsing template [%s]:", path.Clean(templateFilePath)), err)
+	}
+
+	stubs := []yaml.Node{}
+
+	// a stub file may hold several stubs separated by ---, so that they can
+	// all be passed on one pipe.
+	for _, stubFilePath := range stubFilePaths {
+		stubFile, err := readFile(stubFilePath)
+		if err != nil {
+			log.Fatalln(fmt.Sprintf("error reading stub [%s]:", path.Clean(stubFilePath)), err)
+		}
+
+		stubYAML, err := yaml.ParseMulti(sourceName(stubFilePath), stubFile)
+		if err != nil {
+			log.Fatalln(fmt.Sprintf("error parsing stub [%s]:", path.Clean(stubFilePath)), err)
+		}
+
+		stubs = append(stubs, stubYAML...)
+	}
+
+	flowed, err := env.CascadeEach(templates, stubs...)
+	if err != nil {
+		log.Fatalln("error generating manifest:", err)
+	}
+
+	for _, manifest := range flowed {
+		yaml, err := candiedyaml.Marshal(manifest)
+		if err != nil {
+			log.Fatalln("error marshalling manifest:", err)
+		}
+
+		if split {
+			fmt.Println("---")
+		}
+
+		fmt.Println(string(yaml))
+	}
+}
+
+// readVariables reads the variables from the vars files, in order, and from
+// the key=value pairs given with --var, which take precedence. Without any,
+// there are no variables and templates may use vars for nodes of their own.
+func readVariables(varsFilePaths []string, vars []string) map[string]yaml.Node {
+	if len(varsFilePaths) == 0 && len(vars) == 0 {
+		return nil
+	}
+
+	variables := map[string]yaml.Node{}
+
+	for _, varsFilePath := range varsFilePaths {
+		varsFile, err := readFile(varsFilePath)
+		if err != nil {
+			log.Fatalln(fmt.Sprintf("error reading vars file [%s]:", path.Clean(varsFilePath)), err)
+		}
+
+		varsYAML, err := yaml.Parse(sourceName(varsFilePath), varsFile)
+		if err != nil {
+			log.Fatalln(fmt.Sprintf("error parsing vars file [%s]:", path.Clean(varsFilePath)), err)
+		}
+
+		varsMap, ok := varsYAML.Value().(map[string]yaml.Node)
+		if !ok {
+			log.Fatalln(fmt.Sprintf("error parsing vars file [%s]:", path.Clean(varsFilePath)), "not a map")
+		}
+
+		for key, val := range varsMap {
+			variables[key] = val
+		}
+	}
+
+	for _, v := range vars {
+		segments := strings.SplitN(v, "=", 2)
+		if len(segments) != 2 || segments[0] == "" {
+			log.Fatalln(fmt.Sprintf("error parsing variable [%s]:", v), "expected key=value")
+		}
+
+		variables[segments[0]] = yaml.NewNode(segments[1], "--var")
+	}
+
+	return variables
+}
+
+func diff(aFilePath, bFilePath string, separator string) {
+	checkStdin([]string{aFilePath, bFilePath})
+
+	aFile, err := readFile(aFilePath)
+	if err != nil {
+		log.Fatalln(fmt.Sprintf("error reading a [%s]:", path.Clean(aFilePath)), err)
+	}
+
+	aYAML, err := yaml.Parse(sourceName(aFilePath), aFile)
+	if err != nil {
+		log.Fatalln(fmt.Sprintf("error parsing a [%s]:", path.Clean(aFilePath)), err)
+	}
+
+	bFile, err := readFile(bFilePath)
+	if err != nil {
+		log.Fatalln(fmt.Sprintf("error reading b [%s]:", path.Clean(bFilePath)), err)
+	}
+
+	bYAML, err := yaml.Parse(sourceName(bFilePath), bFile)
+	if err != nil {
+		log.Fatalln(fmt.Sprintf("error parsing b [%s]:", path.Clean(bFilePath)), err)
+	}
+
+	diffs := compare.Compare(aYAML, bYAML)
+
+	if len(diffs) == 0 {
+		fmt.Println("no differences!")
+		return
+	}
+
+	for _, diff := range diffs {
+		fmt.Println("Difference in", strings.Join(diff.Path, "."))
+
+		if diff.A != nil {
+			ayaml, err := candiedyaml.Marshal(diff.A)
+			if err != nil {
+				panic(err)
+			}
+
+			fmt.Printf("  %s has:\n    \x1b[31m%s\x1b[0m\n", diff.A.Origin(), strings.Replace(string(ayaml), "\n", "\n    ", -1))
+		}
+
+		if diff.B != nil {
+			byaml, err := candiedyaml.Marshal(diff.B)
+			if err != nil {
+				panic(err)
+			}
+
+			fmt.Printf("  %s has:\n    \x1b[32m%s\x1b[0m\n", diff.B.Origin(), strings.Replace(string(byaml), "\n", "\n    ", -1))
+		}
+
+		fmt.Print(separator)
+	}
+}
+
+// stdinPath is the file argument standing for standard input.
+const stdinPath = "-"
+
+// stdinArg stands in for the stdinPath arguments while the cli package
+// parses them, as it takes any argument starting with - for a flag and moves
+// it before the others. Command line arguments cannot hold it themselves.
+const stdinArg = "\x00stdin"
+
+// escapeStdin replaces the stdinPath arguments of the command by stdinArg.
+// The values of its flags are left alone, so that they still read -.
+func escapeStdin(commands []cli.Command, args []string) []string {
+	escaped := append([]string{}, args...)
+
+	if len(args) < 2 {
+		return escaped
+	}
+
+	switches := map[string]bool{}
+
+	for _, command := range commands {
+		if !command.HasName(args[1]) {
+			continue
+		}
+
+		for _, flag := range append(command.Flags, cli.HelpFlag) {
+			switch f := flag.(type) {
+			case cli.BoolFlag:
+				addFlagNames(switches, f.Name)
+			case cli.BoolTFlag:
+				addFlagNames(switches, f.Name)
+			}
+		}
+	}
+
+	flagValue := false
+	terminated := false
+
+	for i, arg := range args[2:] {
+		switch {
+		case flagValue:
+			flagValue = false
+		case arg == stdinPath:
+			escaped[i+2] = stdinArg
+		case terminated:
+		case arg == "--":
+			terminated = true
+		case strings.HasPrefix(arg, "-") && !strings.Contains(arg, "="):
+			flagValue = !switches[strings.TrimLeft(arg, "-")]
+		}
+	}
+
+	return escaped
+}
+
+// addFlagNames adds the names of a flag, given as "name, n", to the set.
+func addFlagNames(names map[string]bool, name string) {
+	for _, n := range strings.Split(name, ",") {
+		names[strings.TrimSpace(n)] = true
+	}
+}
+
+func unescapeStdin(args []string) []string {
+	unescaped := make([]string, len(args))
+
+	for i, arg := range args {
+		unescaped[i] = arg
+		if arg == stdinArg {
+			unescaped[i] = stdinPath
+		}
+	}
+
+	return unescaped
+}
+
+// readFile reads the file at the path, or standard input for -.
+func readFile(filePath string) ([]byte, error) {
+	if filePath == stdinPath {
+		return ioutil.ReadAll(os.Stdin)
+	}
+
+	return ioutil.ReadFile(filePath)
+}
+
+func sourceName(filePath string) string {
+	if filePath == stdinPath {
+		return "<stdin>"
+	}
+
+	return filePath
+}
+
+// checkStdin makes sure standard input is read for one argument at most.
+func checkStdin(filePaths []string) {
+	count := 0
+
+	for _, filePath := range filePaths {
+		if filePath == stdinPath {
+			count++
+		}
+	}
+
+	if count > 1 {
+		log.Fatalln("error: only one argument can be read from stdin (-)")
+	}
+}
@@ -85,7 +85,7 @@ func listToMap(list []yaml.Node) map[string]yaml.Node {
 			}
 		}
 
-		toMap[name] = yaml.NewNode(newMap, val.SourceName())
+		toMap[name] = yaml.SubstituteNode(newMap, val)
 	}
 
 	return toMap
@@ -153,14 +153,14 @@ func jobMap(jobs []yaml.Node) map[string]yaml.Node {
 
 	for index, job := range jobs {
 		attrs, ok := job.Value().(map[string]yaml.Node)
-		attrs["index"] = yaml.NewNode(index, job.SourceName())
+		attrs["index"] = yaml.SubstituteNode(index, job)
 
 		name, ok := yaml.FindString(job, "name")
 		if !ok {
 			panic("job without string name")
 		}
 
-		byName[name] = yaml.NewNode(attrs, job.SourceName())
+		byName[name] = yaml.SubstituteNode(attrs, job)
 	}
 
 	return byName
@@ -27,7 +27,7 @@ foo: 2
 `)
 
 			It("reports one difference with the key as the path", func() {
-				Expect(Compare(a, b)).To(Equal([]Diff{
+				Expect(Compare(a, b)).To(EqualDiffs([]Diff{
 					{
 						A:    parseYAML("1"),
 						B:    parseYAML("2"),
@@ -51,7 +51,7 @@ foo:
 `)
 
 			It("reports one difference for the nested difference, not the wholistic one", func() {
-				Expect(Compare(a, b)).To(Equal([]Diff{
+				Expect(Compare(a, b)).To(EqualDiffs([]Diff{
 					{
 						A:    parseYAML("1"),
 						B:    parseYAML("2"),
@@ -74,7 +74,7 @@ foo: 2
 `)
 
 			It("reports one difference with the different nodes", func() {
-				Expect(Compare(a, b)).To(Equal([]Diff{
+				Expect(Compare(a, b)).To(EqualDiffs([]Diff{
 					{
 						A:    parseYAML("bar: 1"),
 						B:    parseYAML("2"),
@@ -95,7 +95,7 @@ foo: 1
 `)
 
 			It("reports one difference", func() {
-				Expect(Compare(a, b)).To(Equal([]Diff{
+				Expect(Compare(a, b)).To(EqualDiffs([]Diff{
 					{
 						A:    parseYAML("1"),
 						B:    nil,
@@ -116,7 +116,7 @@ foo: 1
 `)
 
 			It("reports one difference", func() {
-				Expect(Compare(a, b)).To(Equal([]Diff{
+				Expect(Compare(a, b)).To(EqualDiffs([]Diff{
 					Diff{
 						A:    nil,
 						B:    parseYAML("1"),
@@ -139,7 +139,7 @@ bar: 2
 			It("reports both differences", func() {
 				diff := Compare(a, b)
 
-				Expect(diff).To(ContainElement(
+				Expect(diff).To(ContainDiff(
 					Diff{
 						A:    parseYAML("1"),
 						B:    nil,
@@ -147,7 +147,7 @@ bar: 2
 					},
 				))
 
-				Expect(diff).To(ContainElement(
+				Expect(diff).To(ContainDiff(
 					Diff{
 						A:    nil,
 						B:    parseYAML("2"),
@@ -184,7 +184,7 @@ foo:
 `)
 
 				It("reports no differences", func() {
-					Expect(Compare(a, b)).To(Equal([]Diff{
+					Expect(Compare(a, b)).To(EqualDiffs([]Diff{
 						{
 							A:    parseYAML("1"),
 							B:    parseYAML("2"),
@@ -209,7 +209,7 @@ foo:
 `)
 
 			It("reports one difference with the index in the path", func() {
-				Expect(Compare(a, b)).To(Equal([]Diff{
+				Expect(Compare(a, b)).To(EqualDiffs([]Diff{
 					{
 						A:    parseYAML("1"),
 						B:    parseYAML("2"),
@@ -232,7 +232,7 @@ foo:
 `)
 
 			It("reports one difference with the index in the path", func() {
-				Expect(Compare(a, b)).To(Equal([]Diff{
+				Expect(Compare(a, b)).To(EqualDiffs([]Diff{
 					{
 						A:    parseYAML("[hello, world]"),
 						B:    parseYAML("42"),
@@ -266,7 +266,7 @@ jobs:
 
 				Expect(diff).To(HaveLen(2))
 
-				Expect(diff).To(ContainElement(
+				Expect(diff).To(ContainDiff(
 					Diff{
 						A:    parseYAML("0"),
 						B:    parseYAML("1"),
@@ -274,7 +274,7 @@ jobs:
 					},
 				))
 
-				Expect(diff).To(ContainElement(
+				Expect(diff).To(ContainDiff(
 					Diff{
 						A:    parseYAML("1"),
 						B:    parseYAML("0"),
@@ -304,7 +304,7 @@ jobs:
 			It("reports it as different", func() {
 				diff := Compare(a, b)
 
-				Expect(diff).To(Equal([]Diff{
+				Expect(diff).To(EqualDiffs([]Diff{
 					Diff{
 						A:    nil,
 						B:    parseYAML("name: b\nvalue: bar\nindex: 1\n"),
@@ -357,7 +357,7 @@ foo:
 `)
 
 			It("reports each difference", func() {
-				Expect(Compare(a, b)).To(Equal([]Diff{
+				Expect(Compare(a, b)).To(EqualDiffs([]Diff{
 					Diff{
 						A:    parseYAML("2"),
 						B:    nil,
@@ -390,7 +390,7 @@ foo:
 `)
 
 			It("reports one difference", func() {
-				Expect(Compare(a, b)).To(Equal([]Diff{
+				Expect(Compare(a, b)).To(EqualDiffs([]Diff{
 					Diff{
 						A:    nil,
 						B:    parseYAML("2"),
@@ -0,0 +1,89 @@
+package compare
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+// EqualDiffs compares diffs by their paths and the equivalence of their
+// nodes, ignoring where the nodes originate from.
+func EqualDiffs(expected []Diff) *EqualDiffsMatcher {
+	return &EqualDiffsMatcher{Expected: expected}
+}
+
+type EqualDiffsMatcher struct {
+	Expected []Diff
+}
+
+func (matcher *EqualDiffsMatcher) Match(actual interface{}) (success bool, err error) {
+	diffs, ok := actual.([]Diff)
+	if !ok {
+		return false, fmt.Errorf("Not a list of diffs: %#v", actual)
+	}
+
+	if len(diffs) != len(matcher.Expected) {
+		return false, nil
+	}
+
+	for i, diff := range diffs {
+		if !equivalentDiffs(diff, matcher.Expected[i]) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func equivalentDiffs(a, b Diff) bool {
+	return reflect.DeepEqual(a.Path, b.Path) && equivalent(a.A, b.A) && equivalent(a.B, b.B)
+}
+
+func equivalent(a, b yaml.Node) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return a.EquivalentToNode(b)
+}
+
+func (matcher *EqualDiffsMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected\n\t%#v\nto equal\n\t%#v", actual, matcher.Expected)
+}
+
+func (matcher *EqualDiffsMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected\n\t%#v\nnot to equal\n\t%#v", actual, matcher.Expected)
+}
+
+// ContainDiff is like ContainElement, but compares diffs like EqualDiffs.
+func ContainDiff(expected Diff) *ContainDiffMatcher {
+	return &ContainDiffMatcher{Expected: expected}
+}
+
+type ContainDiffMatcher struct {
+	Expected Diff
+}
+
+func (matcher *ContainDiffMatcher) Match(actual interface{}) (success bool, err error) {
+	diffs, ok := actual.([]Diff)
+	if !ok {
+		return false, fmt.Errorf("Not a list of diffs: %#v", actual)
+	}
+
+	for _, diff := range diffs {
+		if equivalentDiffs(diff, matcher.Expected) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (matcher *ContainDiffMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected\n\t%#v\nto contain\n\t%#v", actual, matcher.Expected)
+}
+
+func (matcher *ContainDiffMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected\n\t%#v\nnot to contain\n\t%#v", actual, matcher.Expected)
+}
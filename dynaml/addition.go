@@ -11,28 +11,36 @@ type AdditionExpr struct {
 	B Expression
 }
 
-func (e AdditionExpr) Evaluate(binding Binding) (yaml.Node, bool) {
-	a, ok := e.A.Evaluate(binding)
+func (e AdditionExpr) Evaluate(binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	a, info, ok := e.A.Evaluate(binding)
 	if !ok {
-		return nil, false
+		return nil, info, false
 	}
 
-	b, ok := e.B.Evaluate(binding)
-	if !ok {
-		return nil, false
+	if isExpression(a) {
+		return unresolvedOperand(e, info, binding)
 	}
 
-	aint, ok := a.Value().(int64)
+	b, info, ok := e.B.Evaluate(binding)
 	if !ok {
-		return nil, false
+		return nil, info, false
+	}
+
+	if isExpression(b) {
+		return unresolvedOperand(e, info, binding)
+	}
+
+	aint, bint, ok := asInts(a, b)
+	if ok {
+		return node(aint+bint, binding), info, true
 	}
 
-	bint, ok := b.Value().(int64)
+	afloat, bfloat, ok := asFloats(a, b)
 	if !ok {
-		return nil, false
+		return info.Error("cannot add %s to %s", typeName(b), typeName(a))
 	}
 
-	return node(aint + bint), true
+	return node(afloat+bfloat, binding), info, true
 }
 
 func (e AdditionExpr) String() string {
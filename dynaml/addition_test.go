@@ -1,6 +1,8 @@
 package dynaml
 
 import (
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -15,6 +17,14 @@ var _ = Describe("addition", func() {
 		Expect(expr).To(EvaluateAs(5, FakeBinding{}))
 	})
 
+	Context("when either side is a float", func() {
+		It("adds both as floats", func() {
+			Expect(AdditionExpr{IntegerExpr{2}, FloatExpr{0.5}}).To(EvaluateAs(2.5, FakeBinding{}))
+			Expect(AdditionExpr{FloatExpr{0.5}, IntegerExpr{2}}).To(EvaluateAs(2.5, FakeBinding{}))
+			Expect(AdditionExpr{FloatExpr{0.5}, FloatExpr{0.5}}).To(EvaluateAs(1.0, FakeBinding{}))
+		})
+	})
+
 	Context("when the left-hand side is not an integer", func() {
 		It("fails", func() {
 			expr := AdditionExpr{
@@ -24,6 +34,16 @@ var _ = Describe("addition", func() {
 
 			Expect(expr).To(FailToEvaluate(FakeBinding{}))
 		})
+
+		It("reports the mismatching types", func() {
+			expr := AdditionExpr{
+				IntegerExpr{2},
+				StringExpr{"lol"},
+			}
+
+			_, info, _ := expr.Evaluate(FakeBinding{})
+			Expect(info.Issue).To(Equal("cannot add string to int"))
+		})
 	})
 
 	Context("when the right-hand side is not an integer", func() {
@@ -36,4 +56,24 @@ var _ = Describe("addition", func() {
 			Expect(expr).To(FailToEvaluate(FakeBinding{}))
 		})
 	})
+
+	Context("when an operand is not resolved yet", func() {
+		It("stays unresolved, passing on why", func() {
+			expr := AdditionExpr{
+				IntegerExpr{1},
+				ReferenceExpr{[]string{"foo"}},
+			}
+
+			binding := FakeBinding{
+				FoundReferences: map[string]yaml.Node{
+					"foo": node(ReferenceExpr{[]string{"bar"}}, nil),
+				},
+			}
+
+			result, info, ok := expr.Evaluate(binding)
+			Expect(ok).To(BeTrue())
+			Expect(result.Value()).To(Equal(expr))
+			Expect(info.Issue).To(Equal("foo is not resolved yet"))
+		})
+	})
 })
@@ -0,0 +1,52 @@
+package dynaml
+
+import (
+	"fmt"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+type AndExpr struct {
+	A Expression
+	B Expression
+}
+
+func (e AndExpr) Evaluate(binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	a, info, ok := e.A.Evaluate(binding)
+	if !ok {
+		return nil, info, false
+	}
+
+	if isExpression(a) {
+		return unresolvedOperand(e, info, binding)
+	}
+
+	abool, ok := a.Value().(bool)
+	if !ok {
+		return info.Error("left-hand side of && must be a bool, but is %s", typeName(a))
+	}
+
+	if !abool {
+		return node(false, binding), info, true
+	}
+
+	b, info, ok := e.B.Evaluate(binding)
+	if !ok {
+		return nil, info, false
+	}
+
+	if isExpression(b) {
+		return unresolvedOperand(e, info, binding)
+	}
+
+	bbool, ok := b.Value().(bool)
+	if !ok {
+		return info.Error("right-hand side of && must be a bool, but is %s", typeName(b))
+	}
+
+	return node(bbool, binding), info, true
+}
+
+func (e AndExpr) String() string {
+	return fmt.Sprintf("%s && %s", e.A, e.B)
+}
@@ -0,0 +1,36 @@
+package dynaml
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("and", func() {
+	It("is true if both sides are", func() {
+		Expect(AndExpr{BooleanExpr{true}, BooleanExpr{true}}).To(EvaluateAs(true, FakeBinding{}))
+		Expect(AndExpr{BooleanExpr{true}, BooleanExpr{false}}).To(EvaluateAs(false, FakeBinding{}))
+	})
+
+	Context("when the left-hand side is false", func() {
+		It("does not evaluate the right-hand side", func() {
+			expr := AndExpr{
+				BooleanExpr{false},
+				FailingExpr{},
+			}
+
+			Expect(expr).To(EvaluateAs(false, FakeBinding{}))
+		})
+	})
+
+	Context("when a side is not a bool", func() {
+		It("fails", func() {
+			Expect(AndExpr{IntegerExpr{1}, BooleanExpr{true}}).To(FailToEvaluate(FakeBinding{}))
+			Expect(AndExpr{BooleanExpr{true}, IntegerExpr{1}}).To(FailToEvaluate(FakeBinding{}))
+		})
+
+		It("reports the offending type", func() {
+			_, info, _ := AndExpr{BooleanExpr{true}, IntegerExpr{1}}.Evaluate(FakeBinding{})
+			Expect(info.Issue).To(Equal("right-hand side of && must be a bool, but is int"))
+		})
+	})
+})
@@ -1,6 +1,10 @@
 package dynaml
 
 import (
+	"fmt"
+	"strings"
+	"sync"
+
 	"github.com/cloudfoundry-incubator/spiff/yaml"
 )
 
@@ -8,44 +12,90 @@ type AutoExpr struct {
 	Path []string
 }
 
-func (e AutoExpr) Evaluate(binding Binding) (yaml.Node, bool) {
-	if len(e.Path) == 3 && e.Path[0] == "resource_pools" && e.Path[2] == "size" {
-		jobs, found := binding.FindFromRoot([]string{"jobs"})
-		if !found {
-			return nil, false
-		}
+func (e AutoExpr) Evaluate(binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	info := DefaultInfo()
 
-		jobsList, ok := jobs.Value().([]yaml.Node)
-		if !ok {
-			return nil, false
-		}
+	rule, found := AutoRules.Lookup(e.Path)
+	if !found {
+		return info.Error("auto is not supported for %s", strings.Join(e.Path, "."))
+	}
 
-		var size int64
+	return rule.Implementation(e.Path, binding)
+}
 
-		for _, job := range jobsList {
-			poolName, ok := yaml.FindString(job, "resource_pool")
-			if !ok {
-				continue
-			}
+func (e AutoExpr) String() string {
+	return "auto"
+}
 
-			if poolName != e.Path[1] {
-				continue
-			}
+// AutoRule computes the value of (( auto )) for the nodes whose path matches
+// Pattern, e.g. "resource_pools.*.size", in which * matches any single step.
+type AutoRule struct {
+	Pattern string
 
-			instances, ok := yaml.FindInt(job, "instances")
-			if !ok {
-				return nil, false
-			}
+	Implementation func(path []string, binding Binding) (yaml.Node, EvaluationInfo, bool)
+}
+
+func (r AutoRule) matches(path []string) bool {
+	steps := strings.Split(r.Pattern, ".")
+	if len(steps) != len(path) {
+		return false
+	}
 
-			size += instances
+	for i, step := range steps {
+		if step != "*" && step != path[i] {
+			return false
 		}
+	}
+
+	return true
+}
+
+// AutoRuleTable holds the rules (( auto )) dispatches on.
+type AutoRuleTable struct {
+	lock  sync.RWMutex
+	rules []AutoRule
+}
+
+func NewAutoRuleTable() *AutoRuleTable {
+	return &AutoRuleTable{}
+}
+
+// Register adds a rule to the table. Rules registered later take precedence,
+// so that the built-in rules can be overridden.
+func (t *AutoRuleTable) Register(rule AutoRule) {
+	if rule.Pattern == "" {
+		panic("dynaml: auto rule without a pattern")
+	}
 
-		return node(size), true
+	if rule.Implementation == nil {
+		panic(fmt.Sprintf("dynaml: auto rule for %s has no implementation", rule.Pattern))
 	}
 
-	return nil, false
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.rules = append(t.rules, rule)
 }
 
-func (e AutoExpr) String() string {
-	return "auto"
+// Lookup finds the rule for the given path.
+func (t *AutoRuleTable) Lookup(path []string) (AutoRule, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	for i := len(t.rules) - 1; i >= 0; i-- {
+		if t.rules[i].matches(path) {
+			return t.rules[i], true
+		}
+	}
+
+	return AutoRule{}, false
+}
+
+// AutoRules is the table used when evaluating (( auto )).
+var AutoRules = NewAutoRuleTable()
+
+// RegisterAutoRule makes (( auto )) supported for the paths matching the
+// rule's pattern.
+func RegisterAutoRule(rule AutoRule) {
+	AutoRules.Register(rule)
 }
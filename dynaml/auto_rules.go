@@ -0,0 +1,226 @@
+package dynaml
+
+import (
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+func init() {
+	RegisterAutoRule(AutoRule{
+		Pattern:        "resource_pools.*.size",
+		Implementation: autoResourcePoolSize,
+	})
+
+	RegisterAutoRule(AutoRule{
+		Pattern:        "compilation.workers",
+		Implementation: autoCompilationWorkers,
+	})
+
+	RegisterAutoRule(AutoRule{
+		Pattern:        "disk_pools.*.disk_size",
+		Implementation: autoDiskSize,
+	})
+
+	RegisterAutoRule(AutoRule{
+		Pattern:        "update.max_in_flight",
+		Implementation: autoMaxInFlight,
+	})
+
+	RegisterAutoRule(AutoRule{
+		Pattern:        "jobs.*.update.max_in_flight",
+		Implementation: autoJobMaxInFlight,
+	})
+
+	RegisterAutoRule(AutoRule{
+		Pattern:        "jobs.*.networks.*.static_ips",
+		Implementation: autoStaticIPs,
+	})
+}
+
+// resource_pools.<name>.size sums up the instances of the jobs in the pool.
+func autoResourcePoolSize(path []string, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	jobs, info, ok := findJobs(binding)
+	if !ok {
+		return nil, info, false
+	}
+
+	var size int64
+
+	for _, job := range jobs {
+		poolName, ok := yaml.FindString(job, "resource_pool")
+		if !ok {
+			continue
+		}
+
+		if poolName != path[1] {
+			continue
+		}
+
+		instances, ok := jobInstances(job)
+		if !ok {
+			return info.Error("instances of job '%s' is not an int", jobName(job))
+		}
+
+		size += instances
+	}
+
+	return node(size, binding), info, true
+}
+
+// compilation.workers allows the packages of all jobs with instances to be
+// compiled in parallel.
+func autoCompilationWorkers(path []string, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	jobs, info, ok := findJobs(binding)
+	if !ok {
+		return nil, info, false
+	}
+
+	var workers int64
+
+	for _, job := range jobs {
+		instances, ok := jobInstances(job)
+		if !ok {
+			return info.Error("instances of job '%s' is not an int", jobName(job))
+		}
+
+		if instances > 0 {
+			workers++
+		}
+	}
+
+	if workers == 0 {
+		workers = 1
+	}
+
+	return node(workers, binding), info, true
+}
+
+// disk_pools.<name>.disk_size is the largest persistent_disk of the jobs
+// using the pool.
+func autoDiskSize(path []string, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	jobs, info, ok := findJobs(binding)
+	if !ok {
+		return nil, info, false
+	}
+
+	var size int64
+	found := false
+
+	for _, job := range jobs {
+		poolName, ok := yaml.FindString(job, "persistent_disk_pool")
+		if !ok || poolName != path[1] {
+			continue
+		}
+
+		disk, ok := yaml.Find(job, "persistent_disk")
+		if !ok {
+			continue
+		}
+
+		diskSize, ok := disk.Value().(int64)
+		if !ok {
+			return info.Error("persistent_disk of job '%s' is not an int", jobName(job))
+		}
+
+		if !found || diskSize > size {
+			size = diskSize
+			found = true
+		}
+	}
+
+	if !found {
+		return info.Error("no job using disk pool %s has a persistent_disk", path[1])
+	}
+
+	return node(size, binding), info, true
+}
+
+// update.max_in_flight updates a quarter of the instances of the largest job
+// at a time.
+func autoMaxInFlight(path []string, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	jobs, info, ok := findJobs(binding)
+	if !ok {
+		return nil, info, false
+	}
+
+	var largest int64
+
+	for _, job := range jobs {
+		instances, ok := jobInstances(job)
+		if !ok {
+			return info.Error("instances of job '%s' is not an int", jobName(job))
+		}
+
+		if instances > largest {
+			largest = instances
+		}
+	}
+
+	return node(maxInFlight(largest), binding), info, true
+}
+
+// jobs.<name>.update.max_in_flight updates a quarter of the job's instances
+// at a time.
+func autoJobMaxInFlight(path []string, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	instances, issue, ok := findInstanceCount(binding)
+	if !ok {
+		return DefaultInfo().Error("%s", issue)
+	}
+
+	return node(maxInFlight(int64(instances)), binding), DefaultInfo(), true
+}
+
+func maxInFlight(instances int64) int64 {
+	inFlight := (instances + 3) / 4
+	if inFlight < 1 {
+		return 1
+	}
+
+	return inFlight
+}
+
+// jobs.<name>.networks.<network>.static_ips assigns a static IP of the
+// network to each instance of the job, failing if there are not enough.
+func autoStaticIPs(path []string, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	instances, issue, ok := findInstanceCount(binding)
+	if !ok {
+		return DefaultInfo().Error("%s", issue)
+	}
+
+	if instances == 0 {
+		return node([]yaml.Node{}, binding), DefaultInfo(), true
+	}
+
+	indices := make([]int, instances)
+	for i := range indices {
+		indices[i] = i
+	}
+
+	return generateStaticIPs(binding, indices)
+}
+
+func findJobs(binding Binding) ([]yaml.Node, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	jobs, found := binding.FindFromRoot([]string{"jobs"})
+	if !found {
+		_, info, _ = info.Error("jobs not found")
+		return nil, info, false
+	}
+
+	jobsList, ok := jobs.Value().([]yaml.Node)
+	if !ok {
+		_, info, _ = info.Error("jobs must be a list, but is %s", typeName(jobs))
+		return nil, info, false
+	}
+
+	return jobsList, info, true
+}
+
+func jobInstances(job yaml.Node) (int64, bool) {
+	return yaml.FindInt(job, "instances")
+}
+
+func jobName(job yaml.Node) string {
+	name, _ := yaml.FindString(job, "name")
+	return name
+}
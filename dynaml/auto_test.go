@@ -53,4 +53,160 @@ var _ = Describe("autos", func() {
 			})
 		})
 	})
+
+	jobs := FakeBinding{
+		FoundFromRoot: map[string]yaml.Node{
+			"jobs": parseYAML(`
+- name: some_job
+  instances: 3
+  persistent_disk_pool: some_disks
+  persistent_disk: 1024
+- name: some_other_job
+  instances: 9
+  persistent_disk_pool: some_disks
+  persistent_disk: 4096
+- name: idle_job
+  instances: 0
+`),
+		},
+	}
+
+	Context("when the path is compilation.workers", func() {
+		expr := AutoExpr{[]string{"compilation", "workers"}}
+
+		It("counts the jobs with instances", func() {
+			Expect(expr).To(EvaluateAs(2, jobs))
+		})
+	})
+
+	Context("when the path is disk_pools.*.disk_size", func() {
+		It("takes the largest persistent disk of the jobs using the pool", func() {
+			expr := AutoExpr{[]string{"disk_pools", "some_disks", "disk_size"}}
+
+			Expect(expr).To(EvaluateAs(4096, jobs))
+		})
+
+		It("fails if no job using the pool has a persistent disk", func() {
+			expr := AutoExpr{[]string{"disk_pools", "other_disks", "disk_size"}}
+
+			_, info, ok := expr.Evaluate(jobs)
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("no job using disk pool other_disks has a persistent_disk"))
+		})
+	})
+
+	Context("when the path is update.max_in_flight", func() {
+		It("updates a quarter of the largest job's instances at a time", func() {
+			expr := AutoExpr{[]string{"update", "max_in_flight"}}
+
+			Expect(expr).To(EvaluateAs(3, jobs))
+		})
+	})
+
+	Context("when the path is jobs.*.update.max_in_flight", func() {
+		expr := AutoExpr{[]string{"jobs", "some_job", "update", "max_in_flight"}}
+
+		It("updates a quarter of the job's instances at a time", func() {
+			binding := FakeBinding{
+				FoundReferences: map[string]yaml.Node{
+					"instances": node(5, nil),
+				},
+			}
+
+			Expect(expr).To(EvaluateAs(2, binding))
+		})
+
+		It("updates at least one instance at a time", func() {
+			binding := FakeBinding{
+				FoundReferences: map[string]yaml.Node{
+					"instances": node(0, nil),
+				},
+			}
+
+			Expect(expr).To(EvaluateAs(1, binding))
+		})
+	})
+
+	Context("when the path is jobs.*.networks.*.static_ips", func() {
+		expr := AutoExpr{[]string{"jobs", "some_job", "networks", "cf1", "static_ips"}}
+
+		binding := func(instances int) FakeBinding {
+			return FakeBinding{
+				FoundReferences: map[string]yaml.Node{
+					"name":      node("cf1", nil),
+					"instances": node(instances, nil),
+				},
+				FoundFromRoot: map[string]yaml.Node{
+					"networks.cf1.subnets": parseYAML(`
+- static:
+  - 10.10.16.10 - 10.10.16.12
+`),
+				},
+			}
+		}
+
+		It("assigns a static IP to each instance", func() {
+			Expect(expr).To(EvaluateAs([]yaml.Node{
+				node("10.10.16.10", nil),
+				node("10.10.16.11", nil),
+			}, binding(2)))
+		})
+
+		It("fails if there are not enough static IPs", func() {
+			Expect(expr).To(FailToEvaluate(binding(4)))
+		})
+	})
+
+	Context("when no rule matches the path", func() {
+		It("fails with an issue naming the path", func() {
+			expr := AutoExpr{[]string{"foo", "bar"}}
+
+			_, info, ok := expr.Evaluate(FakeBinding{})
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("auto is not supported for foo.bar"))
+		})
+	})
+
+	Describe("the rule table", func() {
+		var table *AutoRuleTable
+
+		constant := func(val int) func([]string, Binding) (yaml.Node, EvaluationInfo, bool) {
+			return func(path []string, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+				return node(val, binding), DefaultInfo(), true
+			}
+		}
+
+		BeforeEach(func() {
+			table = NewAutoRuleTable()
+		})
+
+		It("matches wildcards against single steps", func() {
+			table.Register(AutoRule{Pattern: "foo.*.bar", Implementation: constant(1)})
+
+			_, found := table.Lookup([]string{"foo", "x", "bar"})
+			Expect(found).To(BeTrue())
+
+			_, found = table.Lookup([]string{"foo", "x", "y", "bar"})
+			Expect(found).To(BeFalse())
+		})
+
+		It("prefers rules registered later", func() {
+			table.Register(AutoRule{Pattern: "foo.*", Implementation: constant(1)})
+			table.Register(AutoRule{Pattern: "foo.bar", Implementation: constant(2)})
+
+			rule, found := table.Lookup([]string{"foo", "bar"})
+			Expect(found).To(BeTrue())
+			Expect(rule.Pattern).To(Equal("foo.bar"))
+
+			rule, found = table.Lookup([]string{"foo", "baz"})
+			Expect(found).To(BeTrue())
+			Expect(rule.Pattern).To(Equal("foo.*"))
+		})
+
+		It("rejects rules without a pattern", func() {
+			Expect(func() {
+				table.Register(AutoRule{Implementation: constant(1)})
+			}).To(Panic())
+		})
+	})
 })
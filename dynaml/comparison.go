@@ -0,0 +1,120 @@
+package dynaml
+
+import (
+	"fmt"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+// ComparisonExpr orders two numbers or two strings; Operator is one of "<",
+// "<=", ">" and ">=".
+type ComparisonExpr struct {
+	Operator string
+	A        Expression
+	B        Expression
+}
+
+func (e ComparisonExpr) Evaluate(binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	a, info, ok := e.A.Evaluate(binding)
+	if !ok {
+		return nil, info, false
+	}
+
+	if isExpression(a) {
+		return unresolvedOperand(e, info, binding)
+	}
+
+	b, info, ok := e.B.Evaluate(binding)
+	if !ok {
+		return nil, info, false
+	}
+
+	if isExpression(b) {
+		return unresolvedOperand(e, info, binding)
+	}
+
+	order, ok := compareValues(a, b)
+	if !ok {
+		return info.Error("cannot compare %s and %s", typeName(a), typeName(b))
+	}
+
+	var result bool
+
+	switch e.Operator {
+	case "<":
+		result = order < 0
+	case "<=":
+		result = order <= 0
+	case ">":
+		result = order > 0
+	case ">=":
+		result = order >= 0
+	default:
+		return info.Error("unknown comparison '%s'", e.Operator)
+	}
+
+	return node(result, binding), info, true
+}
+
+func (e ComparisonExpr) String() string {
+	return fmt.Sprintf("%s %s %s", e.A, e.Operator, e.B)
+}
+
+// compareValues orders two numbers, promoting ints to floats if needed, or
+// two strings.
+func compareValues(a, b yaml.Node) (int, bool) {
+	aint, bint, ok := asInts(a, b)
+	if ok {
+		return compareInts(aint, bint), true
+	}
+
+	afloat, bfloat, ok := asFloats(a, b)
+	if ok {
+		return compareFloats(afloat, bfloat), true
+	}
+
+	astring, ok := a.Value().(string)
+	if !ok {
+		return 0, false
+	}
+
+	bstring, ok := b.Value().(string)
+	if !ok {
+		return 0, false
+	}
+
+	return compareStrings(astring, bstring), true
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+func compareStrings(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
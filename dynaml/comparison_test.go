@@ -0,0 +1,47 @@
+package dynaml
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("comparison", func() {
+	It("orders integers", func() {
+		Expect(ComparisonExpr{"<", IntegerExpr{1}, IntegerExpr{2}}).To(EvaluateAs(true, FakeBinding{}))
+		Expect(ComparisonExpr{"<", IntegerExpr{2}, IntegerExpr{2}}).To(EvaluateAs(false, FakeBinding{}))
+		Expect(ComparisonExpr{"<=", IntegerExpr{2}, IntegerExpr{2}}).To(EvaluateAs(true, FakeBinding{}))
+		Expect(ComparisonExpr{">", IntegerExpr{3}, IntegerExpr{2}}).To(EvaluateAs(true, FakeBinding{}))
+		Expect(ComparisonExpr{">=", IntegerExpr{1}, IntegerExpr{2}}).To(EvaluateAs(false, FakeBinding{}))
+	})
+
+	It("orders ints and floats by value", func() {
+		Expect(ComparisonExpr{"<", IntegerExpr{1}, FloatExpr{1.5}}).To(EvaluateAs(true, FakeBinding{}))
+		Expect(ComparisonExpr{">=", FloatExpr{2.0}, IntegerExpr{2}}).To(EvaluateAs(true, FakeBinding{}))
+	})
+
+	It("orders strings", func() {
+		Expect(ComparisonExpr{"<", StringExpr{"a"}, StringExpr{"b"}}).To(EvaluateAs(true, FakeBinding{}))
+		Expect(ComparisonExpr{">=", StringExpr{"a"}, StringExpr{"b"}}).To(EvaluateAs(false, FakeBinding{}))
+	})
+
+	Context("when the types do not match", func() {
+		It("fails", func() {
+			expr := ComparisonExpr{"<", IntegerExpr{1}, StringExpr{"lol"}}
+
+			Expect(expr).To(FailToEvaluate(FakeBinding{}))
+		})
+
+		It("reports the mismatching types", func() {
+			expr := ComparisonExpr{"<", IntegerExpr{1}, StringExpr{"lol"}}
+
+			_, info, _ := expr.Evaluate(FakeBinding{})
+			Expect(info.Issue).To(Equal("cannot compare int and string"))
+		})
+	})
+
+	Context("when a side fails", func() {
+		It("fails", func() {
+			Expect(ComparisonExpr{"<", FailingExpr{}, IntegerExpr{1}}).To(FailToEvaluate(FakeBinding{}))
+		})
+	})
+})
@@ -12,46 +12,53 @@ type ConcatenationExpr struct {
 	B Expression
 }
 
-func (e ConcatenationExpr) Evaluate(binding Binding) (yaml.Node, bool) {
-	a, ok := e.A.Evaluate(binding)
+func (e ConcatenationExpr) Evaluate(binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	a, info, ok := e.A.Evaluate(binding)
 	if !ok {
-		return nil, false
+		return nil, info, false
 	}
 
-	b, ok := e.B.Evaluate(binding)
+	if isExpression(a) {
+		return unresolvedOperand(e, info, binding)
+	}
+
+	b, info, ok := e.B.Evaluate(binding)
 	if !ok {
-		return nil, false
+		return nil, info, false
+	}
+
+	if isExpression(b) {
+		return unresolvedOperand(e, info, binding)
 	}
 
-	val, ok := concatenateStringAndInt(a, b)
+	val, ok := concatenateStringAndNumber(a, b)
 	if ok {
-		return node(val), true
+		return node(val, binding), info, true
 	}
 
 	alist, aok := a.Value().([]yaml.Node)
 	blist, bok := b.Value().([]yaml.Node)
 	if aok && bok {
-		return node(append(alist, blist...)), true
+		return node(append(alist, blist...), binding), info, true
 	}
 
-	return nil, false
+	return info.Error("cannot concatenate %s and %s", typeName(a), typeName(b))
 }
 
 func (e ConcatenationExpr) String() string {
 	return fmt.Sprintf("%s %s", e.A, e.B)
 }
 
-func concatenateStringAndInt(a yaml.Node, b yaml.Node) (string, bool) {
+func concatenateStringAndNumber(a yaml.Node, b yaml.Node) (string, bool) {
 	aString, aOk := a.Value().(string)
 	if aOk {
-		bString, bOk := b.Value().(string)
-		if bOk {
-			return aString + bString, true
-		} else {
-			bInt, bOk := b.Value().(int64)
-			if bOk {
-				return aString + strconv.FormatInt(bInt, 10), true
-			}
+		switch bVal := b.Value().(type) {
+		case string:
+			return aString + bVal, true
+		case int64:
+			return aString + strconv.FormatInt(bVal, 10), true
+		case float64:
+			return aString + formatFloat(bVal), true
 		}
 	}
 
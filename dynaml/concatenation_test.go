@@ -32,6 +32,20 @@ var _ = Describe("concatenation", func() {
 				})
 			})
 
+			Context("and the right-hand side is a float", func() {
+				It("formats the float the way YAML does", func() {
+					Expect(ConcatenationExpr{
+						StringExpr{"ratio "},
+						FloatExpr{0.5},
+					}).To(EvaluateAs("ratio 0.5", FakeBinding{}))
+
+					Expect(ConcatenationExpr{
+						StringExpr{"ratio "},
+						FloatExpr{2.0},
+					}).To(EvaluateAs("ratio 2", FakeBinding{}))
+				})
+			})
+
 			Context("and the right-hand side is not an integer", func() {
 				It("fails", func() {
 					expr := ConcatenationExpr{
@@ -64,7 +78,7 @@ var _ = Describe("concatenation", func() {
 					ListExpr{[]Expression{StringExpr{"two"}}},
 				}
 
-				Expect(expr).To(EvaluateAs([]yaml.Node{node("one"), node("two")}, FakeBinding{}))
+				Expect(expr).To(EvaluateAs([]yaml.Node{node("one", nil), node("two", nil)}, FakeBinding{}))
 			})
 		})
 
@@ -79,4 +93,24 @@ var _ = Describe("concatenation", func() {
 			})
 		})
 	})
+
+	Context("when an operand is not resolved yet", func() {
+		It("stays unresolved, passing on why", func() {
+			expr := ConcatenationExpr{
+				ReferenceExpr{[]string{"foo"}},
+				StringExpr{"x"},
+			}
+
+			binding := FakeBinding{
+				FoundReferences: map[string]yaml.Node{
+					"foo": node(ReferenceExpr{[]string{"bar"}}, nil),
+				},
+			}
+
+			result, info, ok := expr.Evaluate(binding)
+			Expect(ok).To(BeTrue())
+			Expect(result.Value()).To(Equal(expr))
+			Expect(info.Issue).To(Equal("foo is not resolved yet"))
+		})
+	})
 })
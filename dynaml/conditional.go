@@ -0,0 +1,41 @@
+package dynaml
+
+import (
+	"fmt"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+// ConditionalExpr evaluates to A or B depending on Condition. Only the
+// chosen branch is evaluated.
+type ConditionalExpr struct {
+	Condition Expression
+	A         Expression
+	B         Expression
+}
+
+func (e ConditionalExpr) Evaluate(binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	cond, info, ok := e.Condition.Evaluate(binding)
+	if !ok {
+		return nil, info, false
+	}
+
+	if isExpression(cond) {
+		return unresolvedOperand(e, info, binding)
+	}
+
+	b, ok := cond.Value().(bool)
+	if !ok {
+		return info.Error("condition must be a bool, but is %s", typeName(cond))
+	}
+
+	if b {
+		return e.A.Evaluate(binding)
+	}
+
+	return e.B.Evaluate(binding)
+}
+
+func (e ConditionalExpr) String() string {
+	return fmt.Sprintf("%s ? %s : %s", e.Condition, e.A, e.B)
+}
@@ -0,0 +1,55 @@
+package dynaml
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("conditional", func() {
+	Context("when the condition is true", func() {
+		It("evaluates only the first branch", func() {
+			expr := ConditionalExpr{
+				BooleanExpr{true},
+				IntegerExpr{1},
+				FailingExpr{},
+			}
+
+			Expect(expr).To(EvaluateAs(1, FakeBinding{}))
+		})
+	})
+
+	Context("when the condition is false", func() {
+		It("evaluates only the second branch", func() {
+			expr := ConditionalExpr{
+				BooleanExpr{false},
+				FailingExpr{},
+				IntegerExpr{2},
+			}
+
+			Expect(expr).To(EvaluateAs(2, FakeBinding{}))
+		})
+	})
+
+	Context("when the condition is not a bool", func() {
+		It("fails", func() {
+			expr := ConditionalExpr{
+				IntegerExpr{1},
+				IntegerExpr{1},
+				IntegerExpr{2},
+			}
+
+			Expect(expr).To(FailToEvaluate(FakeBinding{}))
+		})
+
+		It("reports the offending type", func() {
+			expr := ConditionalExpr{
+				StringExpr{"yes"},
+				IntegerExpr{1},
+				IntegerExpr{2},
+			}
+
+			_, info, _ := expr.Evaluate(FakeBinding{})
+			Expect(info.Issue).To(Equal("condition must be a bool, but is string"))
+		})
+	})
+})
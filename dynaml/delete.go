@@ -0,0 +1,17 @@
+package dynaml
+
+import (
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+// DeleteExpr marks a map key or list entry for removal. It evaluates to
+// itself, so that stubs keep it until they are merged into the template.
+type DeleteExpr struct{}
+
+func (e DeleteExpr) Evaluate(binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	return node(e, binding), DefaultInfo(), true
+}
+
+func (e DeleteExpr) String() string {
+	return "delete"
+}
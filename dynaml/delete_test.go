@@ -0,0 +1,12 @@
+package dynaml
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("delete", func() {
+	It("evaluates to itself", func() {
+		Expect(DeleteExpr{}).To(EvaluateAs(DeleteExpr{}, FakeBinding{}))
+	})
+})
@@ -0,0 +1,79 @@
+package dynaml
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("division", func() {
+	It("divides both numbers", func() {
+		expr := DivisionExpr{
+			IntegerExpr{7},
+			IntegerExpr{2},
+		}
+
+		Expect(expr).To(EvaluateAs(3, FakeBinding{}))
+	})
+
+	It("truncates the quotient of two integers", func() {
+		Expect(DivisionExpr{IntegerExpr{-7}, IntegerExpr{2}}).To(EvaluateAs(-3, FakeBinding{}))
+	})
+
+	Context("when either side is a float", func() {
+		It("divides both as floats", func() {
+			Expect(DivisionExpr{IntegerExpr{7}, FloatExpr{2}}).To(EvaluateAs(3.5, FakeBinding{}))
+		})
+
+		It("fails for a zero divisor", func() {
+			_, info, ok := DivisionExpr{FloatExpr{1.5}, FloatExpr{0}}.Evaluate(FakeBinding{})
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("division by zero"))
+		})
+	})
+
+	Context("when the left-hand side is not an integer", func() {
+		It("fails", func() {
+			expr := DivisionExpr{
+				StringExpr{"lol"},
+				IntegerExpr{2},
+			}
+
+			Expect(expr).To(FailToEvaluate(FakeBinding{}))
+		})
+
+		It("reports the mismatching types", func() {
+			expr := DivisionExpr{
+				StringExpr{"lol"},
+				IntegerExpr{2},
+			}
+
+			_, info, _ := expr.Evaluate(FakeBinding{})
+			Expect(info.Issue).To(Equal("cannot divide string by int"))
+		})
+	})
+
+	Context("when the right-hand side is not an integer", func() {
+		It("fails", func() {
+			expr := DivisionExpr{
+				IntegerExpr{2},
+				StringExpr{"lol"},
+			}
+
+			Expect(expr).To(FailToEvaluate(FakeBinding{}))
+		})
+	})
+
+	Context("when the right-hand side is zero", func() {
+		It("fails with a clear reason", func() {
+			expr := DivisionExpr{
+				IntegerExpr{2},
+				IntegerExpr{0},
+			}
+
+			Expect(expr).To(FailToEvaluate(FakeBinding{}))
+
+			_, info, _ := expr.Evaluate(FakeBinding{})
+			Expect(info.Issue).To(Equal("division by zero"))
+		})
+	})
+})
@@ -16,27 +16,62 @@ type Rule uint8
 const (
 	RuleUnknown Rule = iota
 	RuleDynaml
+	RuleTemporary
 	RuleExpression
-	RuleLevel2
+	RuleLevel6
+	RuleConditional
+	RuleLevel5
 	RuleOr
-	RuleLevel1
-	RuleConcatenation
+	RuleLevel4
+	RuleAnd
+	RuleLevel3
+	RuleEqual
+	RuleNotEqual
+	RuleLessOrEqual
+	RuleLess
+	RuleGreaterOrEqual
+	RuleGreater
+	RuleLevel2
 	RuleAddition
 	RuleSubtraction
+	RuleConcatenation
+	RuleLevel1
+	RuleMultiplication
+	RuleDivision
+	RuleModulo
 	RuleLevel0
 	RuleGrouped
+	RuleNot
 	RuleCall
 	RuleArguments
+	RuleArgument
+	RuleProjection
 	RuleName
+	RuleLambda
+	RuleParameters
+	RuleParameter
 	RuleComma
+	RuleFloat
 	RuleInteger
 	RuleString
 	RuleBoolean
 	RuleNil
 	RuleList
+	RuleStartList
 	RuleContents
+	RuleMap
+	RuleStartMap
+	RuleAssignments
+	RuleAssignment
+	RuleKey
 	RuleMerge
+	RuleMergeNone
+	RuleMergeReplace
+	RuleMergeOn
+	RuleMergeKey
+	RuleMergePath
 	RuleAuto
+	RuleDelete
 	RuleReference
 	Rulews
 	Rulereq_ws
@@ -49,27 +84,62 @@ const (
 var Rul3s = [...]string{
 	"Unknown",
 	"Dynaml",
+	"Temporary",
 	"Expression",
-	"Level2",
+	"Level6",
+	"Conditional",
+	"Level5",
 	"Or",
-	"Level1",
-	"Concatenation",
+	"Level4",
+	"And",
+	"Level3",
+	"Equal",
+	"NotEqual",
+	"LessOrEqual",
+	"Less",
+	"GreaterOrEqual",
+	"Greater",
+	"Level2",
 	"Addition",
 	"Subtraction",
+	"Concatenation",
+	"Level1",
+	"Multiplication",
+	"Division",
+	"Modulo",
 	"Level0",
 	"Grouped",
+	"Not",
 	"Call",
 	"Arguments",
+	"Argument",
+	"Projection",
 	"Name",
+	"Lambda",
+	"Parameters",
+	"Parameter",
 	"Comma",
+	"Float",
 	"Integer",
 	"String",
 	"Boolean",
 	"Nil",
 	"List",
+	"StartList",
 	"Contents",
+	"Map",
+	"StartMap",
+	"Assignments",
+	"Assignment",
+	"Key",
 	"Merge",
+	"MergeNone",
+	"MergeReplace",
+	"MergeOn",
+	"MergeKey",
+	"MergePath",
 	"Auto",
+	"Delete",
 	"Reference",
 	"ws",
 	"req_ws",
@@ -574,7 +644,7 @@ func (t *tokens32) Expand(index int) TokenTree {
 
 type DynamlGrammar struct {
 	Buffer string
-	rules  [26]func() bool
+	rules  [61]func() bool
 	Parse  func(rule ...int) error
 	Reset  func()
 	TokenTree
@@ -703,7 +773,7 @@ func (p *DynamlGrammar) Init() {
 
 	rules = [...]func() bool{
 		nil,
-		/* 0 Dynaml <- <(ws Expression ws !.)> */
+		/* 0 Dynaml <- <(ws (Temporary / Expression) ws !.)> */
 		func() bool {
 			position0, tokenIndex0, depth0 := position, tokenIndex, depth
 			{
@@ -712,20 +782,30 @@ func (p *DynamlGrammar) Init() {
 				if !rules[Rulews]() {
 					goto l0
 				}
-				if !rules[RuleExpression]() {
-					goto l0
+				{
+					position2, tokenIndex2, depth2 := position, tokenIndex, depth
+					if !rules[RuleTemporary]() {
+						goto l3
+					}
+					goto l2
+				l3:
+					position, tokenIndex, depth = position2, tokenIndex2, depth2
+					if !rules[RuleExpression]() {
+						goto l0
+					}
 				}
+			l2:
 				if !rules[Rulews]() {
 					goto l0
 				}
 				{
-					position2, tokenIndex2, depth2 := position, tokenIndex, depth
+					position4, tokenIndex4, depth4 := position, tokenIndex, depth
 					if !matchDot() {
-						goto l2
+						goto l4
 					}
 					goto l0
-				l2:
-					position, tokenIndex, depth = position2, tokenIndex2, depth2
+				l4:
+					position, tokenIndex, depth = position4, tokenIndex4, depth4
 				}
 				depth--
 				add(RuleDynaml, position1)
@@ -735,1194 +815,2979 @@ func (p *DynamlGrammar) Init() {
 			position, tokenIndex, depth = position0, tokenIndex0, depth0
 			return false
 		},
-		/* 1 Expression <- <Level2> */
-		func() bool {
-			position3, tokenIndex3, depth3 := position, tokenIndex, depth
-			{
-				position4 := position
-				depth++
-				if !rules[RuleLevel2]() {
-					goto l3
-				}
-				depth--
-				add(RuleExpression, position4)
-			}
-			return true
-		l3:
-			position, tokenIndex, depth = position3, tokenIndex3, depth3
-			return false
-		},
-		/* 2 Level2 <- <(Or / Level1)> */
+		/* 1 Temporary <- <(('&' 't' 'e' 'm' 'p' 'o' 'r' 'a' 'r' 'y') (req_ws Expression)?)> */
 		func() bool {
 			position5, tokenIndex5, depth5 := position, tokenIndex, depth
 			{
 				position6 := position
 				depth++
+				if buffer[position] != '&' {
+					goto l5
+				}
+				position++
+				if buffer[position] != 't' {
+					goto l5
+				}
+				position++
+				if buffer[position] != 'e' {
+					goto l5
+				}
+				position++
+				if buffer[position] != 'm' {
+					goto l5
+				}
+				position++
+				if buffer[position] != 'p' {
+					goto l5
+				}
+				position++
+				if buffer[position] != 'o' {
+					goto l5
+				}
+				position++
+				if buffer[position] != 'r' {
+					goto l5
+				}
+				position++
+				if buffer[position] != 'a' {
+					goto l5
+				}
+				position++
+				if buffer[position] != 'r' {
+					goto l5
+				}
+				position++
+				if buffer[position] != 'y' {
+					goto l5
+				}
+				position++
 				{
 					position7, tokenIndex7, depth7 := position, tokenIndex, depth
-					if !rules[RuleOr]() {
-						goto l8
+					if !rules[Rulereq_ws]() {
+						goto l7
 					}
-					goto l7
-				l8:
-					position, tokenIndex, depth = position7, tokenIndex7, depth7
-					if !rules[RuleLevel1]() {
-						goto l5
+					if !rules[RuleExpression]() {
+						goto l7
 					}
+					goto l8
+				l7:
+					position, tokenIndex, depth = position7, tokenIndex7, depth7
 				}
-			l7:
+			l8:
 				depth--
-				add(RuleLevel2, position6)
+				add(RuleTemporary, position6)
 			}
 			return true
 		l5:
 			position, tokenIndex, depth = position5, tokenIndex5, depth5
 			return false
 		},
-		/* 3 Or <- <(Level1 req_ws ('|' '|') req_ws Expression)> */
+		/* 2 Expression <- <Level6> */
 		func() bool {
 			position9, tokenIndex9, depth9 := position, tokenIndex, depth
 			{
 				position10 := position
 				depth++
-				if !rules[RuleLevel1]() {
-					goto l9
-				}
-				if !rules[Rulereq_ws]() {
-					goto l9
-				}
-				if buffer[position] != '|' {
-					goto l9
-				}
-				position++
-				if buffer[position] != '|' {
-					goto l9
-				}
-				position++
-				if !rules[Rulereq_ws]() {
-					goto l9
-				}
-				if !rules[RuleExpression]() {
+				if !rules[RuleLevel6]() {
 					goto l9
 				}
 				depth--
-				add(RuleOr, position10)
+				add(RuleExpression, position10)
 			}
 			return true
 		l9:
 			position, tokenIndex, depth = position9, tokenIndex9, depth9
 			return false
 		},
-		/* 4 Level1 <- <(Concatenation / Addition / Subtraction / Level0)> */
+		/* 3 Level6 <- <(Level5 (req_ws Conditional)?)> */
 		func() bool {
 			position11, tokenIndex11, depth11 := position, tokenIndex, depth
 			{
 				position12 := position
 				depth++
+				if !rules[RuleLevel5]() {
+					goto l11
+				}
 				{
 					position13, tokenIndex13, depth13 := position, tokenIndex, depth
-					if !rules[RuleConcatenation]() {
-						goto l14
-					}
-					goto l13
-				l14:
-					position, tokenIndex, depth = position13, tokenIndex13, depth13
-					if !rules[RuleAddition]() {
-						goto l15
+					if !rules[Rulereq_ws]() {
+						goto l13
 					}
-					goto l13
-				l15:
-					position, tokenIndex, depth = position13, tokenIndex13, depth13
-					if !rules[RuleSubtraction]() {
-						goto l16
+					if !rules[RuleConditional]() {
+						goto l13
 					}
-					goto l13
-				l16:
+					goto l14
+				l13:
 					position, tokenIndex, depth = position13, tokenIndex13, depth13
-					if !rules[RuleLevel0]() {
-						goto l11
-					}
 				}
-			l13:
+			l14:
 				depth--
-				add(RuleLevel1, position12)
+				add(RuleLevel6, position12)
 			}
 			return true
 		l11:
 			position, tokenIndex, depth = position11, tokenIndex11, depth11
 			return false
 		},
-		/* 5 Concatenation <- <(Level0 (' ' / '\t' / '\n' / '\r')+ Level1)> */
+		/* 4 Conditional <- <('?' req_ws Expression ws ':' ws Expression)> */
+		func() bool {
+			position15, tokenIndex15, depth15 := position, tokenIndex, depth
+			{
+				position16 := position
+				depth++
+				if buffer[position] != '?' {
+					goto l15
+				}
+				position++
+				if !rules[Rulereq_ws]() {
+					goto l15
+				}
+				if !rules[RuleExpression]() {
+					goto l15
+				}
+				if !rules[Rulews]() {
+					goto l15
+				}
+				if buffer[position] != ':' {
+					goto l15
+				}
+				position++
+				if !rules[Rulews]() {
+					goto l15
+				}
+				if !rules[RuleExpression]() {
+					goto l15
+				}
+				depth--
+				add(RuleConditional, position16)
+			}
+			return true
+		l15:
+			position, tokenIndex, depth = position15, tokenIndex15, depth15
+			return false
+		},
+		/* 5 Level5 <- <(Level4 (req_ws Or)?)> */
 		func() bool {
 			position17, tokenIndex17, depth17 := position, tokenIndex, depth
 			{
 				position18 := position
 				depth++
-				if !rules[RuleLevel0]() {
+				if !rules[RuleLevel4]() {
 					goto l17
 				}
 				{
-					position21, tokenIndex21, depth21 := position, tokenIndex, depth
-					if buffer[position] != ' ' {
-						goto l22
-					}
-					position++
-					goto l21
-				l22:
-					position, tokenIndex, depth = position21, tokenIndex21, depth21
-					if buffer[position] != '\t' {
-						goto l23
-					}
-					position++
-					goto l21
-				l23:
-					position, tokenIndex, depth = position21, tokenIndex21, depth21
-					if buffer[position] != '\n' {
-						goto l24
-					}
-					position++
-					goto l21
-				l24:
-					position, tokenIndex, depth = position21, tokenIndex21, depth21
-					if buffer[position] != '\r' {
-						goto l17
+					position19, tokenIndex19, depth19 := position, tokenIndex, depth
+					if !rules[Rulereq_ws]() {
+						goto l19
 					}
-					position++
-				}
-			l21:
-			l19:
-				{
-					position20, tokenIndex20, depth20 := position, tokenIndex, depth
-					{
-						position25, tokenIndex25, depth25 := position, tokenIndex, depth
-						if buffer[position] != ' ' {
-							goto l26
-						}
-						position++
-						goto l25
-					l26:
-						position, tokenIndex, depth = position25, tokenIndex25, depth25
-						if buffer[position] != '\t' {
-							goto l27
-						}
-						position++
-						goto l25
-					l27:
-						position, tokenIndex, depth = position25, tokenIndex25, depth25
-						if buffer[position] != '\n' {
-							goto l28
-						}
-						position++
-						goto l25
-					l28:
-						position, tokenIndex, depth = position25, tokenIndex25, depth25
-						if buffer[position] != '\r' {
-							goto l20
-						}
-						position++
+					if !rules[RuleOr]() {
+						goto l19
 					}
-				l25:
-					goto l19
-				l20:
-					position, tokenIndex, depth = position20, tokenIndex20, depth20
-				}
-				if !rules[RuleLevel1]() {
-					goto l17
+					goto l20
+				l19:
+					position, tokenIndex, depth = position19, tokenIndex19, depth19
 				}
+			l20:
 				depth--
-				add(RuleConcatenation, position18)
+				add(RuleLevel5, position18)
 			}
 			return true
 		l17:
 			position, tokenIndex, depth = position17, tokenIndex17, depth17
 			return false
 		},
-		/* 6 Addition <- <(Level0 req_ws '+' req_ws Level1)> */
+		/* 6 Or <- <(('|' '|') req_ws Level5)> */
 		func() bool {
-			position29, tokenIndex29, depth29 := position, tokenIndex, depth
+			position21, tokenIndex21, depth21 := position, tokenIndex, depth
 			{
-				position30 := position
+				position22 := position
 				depth++
-				if !rules[RuleLevel0]() {
-					goto l29
-				}
-				if !rules[Rulereq_ws]() {
-					goto l29
+				if buffer[position] != '|' {
+					goto l21
 				}
-				if buffer[position] != '+' {
-					goto l29
+				position++
+				if buffer[position] != '|' {
+					goto l21
 				}
 				position++
 				if !rules[Rulereq_ws]() {
-					goto l29
+					goto l21
 				}
-				if !rules[RuleLevel1]() {
-					goto l29
+				if !rules[RuleLevel5]() {
+					goto l21
 				}
 				depth--
-				add(RuleAddition, position30)
+				add(RuleOr, position22)
 			}
 			return true
-		l29:
-			position, tokenIndex, depth = position29, tokenIndex29, depth29
+		l21:
+			position, tokenIndex, depth = position21, tokenIndex21, depth21
 			return false
 		},
-		/* 7 Subtraction <- <(Level0 req_ws '-' req_ws Level1)> */
+		/* 7 Level4 <- <(Level3 (req_ws And)?)> */
 		func() bool {
-			position31, tokenIndex31, depth31 := position, tokenIndex, depth
+			position23, tokenIndex23, depth23 := position, tokenIndex, depth
 			{
-				position32 := position
+				position24 := position
 				depth++
-				if !rules[RuleLevel0]() {
-					goto l31
+				if !rules[RuleLevel3]() {
+					goto l23
 				}
-				if !rules[Rulereq_ws]() {
-					goto l31
+				{
+					position25, tokenIndex25, depth25 := position, tokenIndex, depth
+					if !rules[Rulereq_ws]() {
+						goto l25
+					}
+					if !rules[RuleAnd]() {
+						goto l25
+					}
+					goto l26
+				l25:
+					position, tokenIndex, depth = position25, tokenIndex25, depth25
 				}
-				if buffer[position] != '-' {
-					goto l31
+			l26:
+				depth--
+				add(RuleLevel4, position24)
+			}
+			return true
+		l23:
+			position, tokenIndex, depth = position23, tokenIndex23, depth23
+			return false
+		},
+		/* 8 And <- <(('&' '&') req_ws Level4)> */
+		func() bool {
+			position27, tokenIndex27, depth27 := position, tokenIndex, depth
+			{
+				position28 := position
+				depth++
+				if buffer[position] != '&' {
+					goto l27
+				}
+				position++
+				if buffer[position] != '&' {
+					goto l27
 				}
 				position++
 				if !rules[Rulereq_ws]() {
-					goto l31
+					goto l27
 				}
-				if !rules[RuleLevel1]() {
-					goto l31
+				if !rules[RuleLevel4]() {
+					goto l27
 				}
 				depth--
-				add(RuleSubtraction, position32)
+				add(RuleAnd, position28)
 			}
 			return true
-		l31:
-			position, tokenIndex, depth = position31, tokenIndex31, depth31
+		l27:
+			position, tokenIndex, depth = position27, tokenIndex27, depth27
 			return false
 		},
-		/* 8 Level0 <- <(Grouped / Call / Boolean / Nil / String / Integer / List / Merge / Auto / Reference)> */
+		/* 9 Level3 <- <(Level2 (req_ws (Equal / NotEqual / LessOrEqual / Less / GreaterOrEqual / Greater))?)> */
 		func() bool {
-			position33, tokenIndex33, depth33 := position, tokenIndex, depth
+			position29, tokenIndex29, depth29 := position, tokenIndex, depth
 			{
-				position34 := position
+				position30 := position
 				depth++
+				if !rules[RuleLevel2]() {
+					goto l29
+				}
 				{
-					position35, tokenIndex35, depth35 := position, tokenIndex, depth
-					if !rules[RuleGrouped]() {
-						goto l36
-					}
-					goto l35
-				l36:
-					position, tokenIndex, depth = position35, tokenIndex35, depth35
-					if !rules[RuleCall]() {
-						goto l37
-					}
-					goto l35
-				l37:
-					position, tokenIndex, depth = position35, tokenIndex35, depth35
-					if !rules[RuleBoolean]() {
-						goto l38
-					}
-					goto l35
-				l38:
-					position, tokenIndex, depth = position35, tokenIndex35, depth35
-					if !rules[RuleNil]() {
-						goto l39
-					}
-					goto l35
-				l39:
-					position, tokenIndex, depth = position35, tokenIndex35, depth35
-					if !rules[RuleString]() {
-						goto l40
-					}
-					goto l35
-				l40:
-					position, tokenIndex, depth = position35, tokenIndex35, depth35
-					if !rules[RuleInteger]() {
-						goto l41
-					}
-					goto l35
-				l41:
-					position, tokenIndex, depth = position35, tokenIndex35, depth35
-					if !rules[RuleList]() {
-						goto l42
-					}
-					goto l35
-				l42:
-					position, tokenIndex, depth = position35, tokenIndex35, depth35
-					if !rules[RuleMerge]() {
-						goto l43
-					}
-					goto l35
-				l43:
-					position, tokenIndex, depth = position35, tokenIndex35, depth35
-					if !rules[RuleAuto]() {
-						goto l44
+					position31, tokenIndex31, depth31 := position, tokenIndex, depth
+					if !rules[Rulereq_ws]() {
+						goto l31
 					}
-					goto l35
-				l44:
-					position, tokenIndex, depth = position35, tokenIndex35, depth35
-					if !rules[RuleReference]() {
+					{
+						position33, tokenIndex33, depth33 := position, tokenIndex, depth
+						if !rules[RuleEqual]() {
+							goto l34
+						}
+						goto l33
+					l34:
+						position, tokenIndex, depth = position33, tokenIndex33, depth33
+						if !rules[RuleNotEqual]() {
+							goto l35
+						}
+						goto l33
+					l35:
+						position, tokenIndex, depth = position33, tokenIndex33, depth33
+						if !rules[RuleLessOrEqual]() {
+							goto l36
+						}
 						goto l33
+					l36:
+						position, tokenIndex, depth = position33, tokenIndex33, depth33
+						if !rules[RuleLess]() {
+							goto l37
+						}
+						goto l33
+					l37:
+						position, tokenIndex, depth = position33, tokenIndex33, depth33
+						if !rules[RuleGreaterOrEqual]() {
+							goto l38
+						}
+						goto l33
+					l38:
+						position, tokenIndex, depth = position33, tokenIndex33, depth33
+						if !rules[RuleGreater]() {
+							goto l31
+						}
 					}
+				l33:
+					goto l32
+				l31:
+					position, tokenIndex, depth = position31, tokenIndex31, depth31
 				}
-			l35:
+			l32:
 				depth--
-				add(RuleLevel0, position34)
+				add(RuleLevel3, position30)
 			}
 			return true
-		l33:
-			position, tokenIndex, depth = position33, tokenIndex33, depth33
+		l29:
+			position, tokenIndex, depth = position29, tokenIndex29, depth29
 			return false
 		},
-		/* 9 Grouped <- <('(' Expression ')')> */
+		/* 10 Equal <- <(('=' '=') req_ws Level2)> */
 		func() bool {
-			position45, tokenIndex45, depth45 := position, tokenIndex, depth
+			position39, tokenIndex39, depth39 := position, tokenIndex, depth
 			{
-				position46 := position
+				position40 := position
 				depth++
-				if buffer[position] != '(' {
-					goto l45
+				if buffer[position] != '=' {
+					goto l39
 				}
 				position++
-				if !rules[RuleExpression]() {
-					goto l45
-				}
-				if buffer[position] != ')' {
-					goto l45
+				if buffer[position] != '=' {
+					goto l39
 				}
 				position++
+				if !rules[Rulereq_ws]() {
+					goto l39
+				}
+				if !rules[RuleLevel2]() {
+					goto l39
+				}
 				depth--
-				add(RuleGrouped, position46)
+				add(RuleEqual, position40)
 			}
 			return true
-		l45:
-			position, tokenIndex, depth = position45, tokenIndex45, depth45
+		l39:
+			position, tokenIndex, depth = position39, tokenIndex39, depth39
 			return false
 		},
-		/* 10 Call <- <(Name '(' Arguments ')')> */
+		/* 11 NotEqual <- <(('!' '=') req_ws Level2)> */
 		func() bool {
-			position47, tokenIndex47, depth47 := position, tokenIndex, depth
+			position41, tokenIndex41, depth41 := position, tokenIndex, depth
 			{
-				position48 := position
+				position42 := position
 				depth++
-				if !rules[RuleName]() {
-					goto l47
+				if buffer[position] != '!' {
+					goto l41
 				}
-				if buffer[position] != '(' {
-					goto l47
+				position++
+				if buffer[position] != '=' {
+					goto l41
 				}
 				position++
-				if !rules[RuleArguments]() {
-					goto l47
+				if !rules[Rulereq_ws]() {
+					goto l41
 				}
-				if buffer[position] != ')' {
-					goto l47
+				if !rules[RuleLevel2]() {
+					goto l41
 				}
-				position++
 				depth--
-				add(RuleCall, position48)
+				add(RuleNotEqual, position42)
 			}
 			return true
-		l47:
-			position, tokenIndex, depth = position47, tokenIndex47, depth47
+		l41:
+			position, tokenIndex, depth = position41, tokenIndex41, depth41
 			return false
 		},
-		/* 11 Arguments <- <(Expression (Comma ws Expression)*)> */
+		/* 12 LessOrEqual <- <(('<' '=') req_ws Level2)> */
 		func() bool {
-			position49, tokenIndex49, depth49 := position, tokenIndex, depth
+			position43, tokenIndex43, depth43 := position, tokenIndex, depth
 			{
-				position50 := position
+				position44 := position
 				depth++
-				if !rules[RuleExpression]() {
+				if buffer[position] != '<' {
+					goto l43
+				}
+				position++
+				if buffer[position] != '=' {
+					goto l43
+				}
+				position++
+				if !rules[Rulereq_ws]() {
+					goto l43
+				}
+				if !rules[RuleLevel2]() {
+					goto l43
+				}
+				depth--
+				add(RuleLessOrEqual, position44)
+			}
+			return true
+		l43:
+			position, tokenIndex, depth = position43, tokenIndex43, depth43
+			return false
+		},
+		/* 13 Less <- <('<' req_ws Level2)> */
+		func() bool {
+			position45, tokenIndex45, depth45 := position, tokenIndex, depth
+			{
+				position46 := position
+				depth++
+				if buffer[position] != '<' {
+					goto l45
+				}
+				position++
+				if !rules[Rulereq_ws]() {
+					goto l45
+				}
+				if !rules[RuleLevel2]() {
+					goto l45
+				}
+				depth--
+				add(RuleLess, position46)
+			}
+			return true
+		l45:
+			position, tokenIndex, depth = position45, tokenIndex45, depth45
+			return false
+		},
+		/* 14 GreaterOrEqual <- <(('>' '=') req_ws Level2)> */
+		func() bool {
+			position47, tokenIndex47, depth47 := position, tokenIndex, depth
+			{
+				position48 := position
+				depth++
+				if buffer[position] != '>' {
+					goto l47
+				}
+				position++
+				if buffer[position] != '=' {
+					goto l47
+				}
+				position++
+				if !rules[Rulereq_ws]() {
+					goto l47
+				}
+				if !rules[RuleLevel2]() {
+					goto l47
+				}
+				depth--
+				add(RuleGreaterOrEqual, position48)
+			}
+			return true
+		l47:
+			position, tokenIndex, depth = position47, tokenIndex47, depth47
+			return false
+		},
+		/* 15 Greater <- <('>' req_ws Level2)> */
+		func() bool {
+			position49, tokenIndex49, depth49 := position, tokenIndex, depth
+			{
+				position50 := position
+				depth++
+				if buffer[position] != '>' {
+					goto l49
+				}
+				position++
+				if !rules[Rulereq_ws]() {
+					goto l49
+				}
+				if !rules[RuleLevel2]() {
 					goto l49
 				}
-			l51:
+				depth--
+				add(RuleGreater, position50)
+			}
+			return true
+		l49:
+			position, tokenIndex, depth = position49, tokenIndex49, depth49
+			return false
+		},
+		/* 16 Level2 <- <(Level1 ((req_ws (Addition / Subtraction)) / Concatenation)*)> */
+		func() bool {
+			position51, tokenIndex51, depth51 := position, tokenIndex, depth
+			{
+				position52 := position
+				depth++
+				if !rules[RuleLevel1]() {
+					goto l51
+				}
+			l53:
+				{
+					position54, tokenIndex54, depth54 := position, tokenIndex, depth
+					{
+						position55, tokenIndex55, depth55 := position, tokenIndex, depth
+						if !rules[Rulereq_ws]() {
+							goto l56
+						}
+						{
+							position57, tokenIndex57, depth57 := position, tokenIndex, depth
+							if !rules[RuleAddition]() {
+								goto l58
+							}
+							goto l57
+						l58:
+							position, tokenIndex, depth = position57, tokenIndex57, depth57
+							if !rules[RuleSubtraction]() {
+								goto l56
+							}
+						}
+					l57:
+						goto l55
+					l56:
+						position, tokenIndex, depth = position55, tokenIndex55, depth55
+						if !rules[RuleConcatenation]() {
+							goto l54
+						}
+					}
+				l55:
+					goto l53
+				l54:
+					position, tokenIndex, depth = position54, tokenIndex54, depth54
+				}
+				depth--
+				add(RuleLevel2, position52)
+			}
+			return true
+		l51:
+			position, tokenIndex, depth = position51, tokenIndex51, depth51
+			return false
+		},
+		/* 17 Addition <- <('+' req_ws Level1)> */
+		func() bool {
+			position59, tokenIndex59, depth59 := position, tokenIndex, depth
+			{
+				position60 := position
+				depth++
+				if buffer[position] != '+' {
+					goto l59
+				}
+				position++
+				if !rules[Rulereq_ws]() {
+					goto l59
+				}
+				if !rules[RuleLevel1]() {
+					goto l59
+				}
+				depth--
+				add(RuleAddition, position60)
+			}
+			return true
+		l59:
+			position, tokenIndex, depth = position59, tokenIndex59, depth59
+			return false
+		},
+		/* 18 Subtraction <- <('-' req_ws Level1)> */
+		func() bool {
+			position61, tokenIndex61, depth61 := position, tokenIndex, depth
+			{
+				position62 := position
+				depth++
+				if buffer[position] != '-' {
+					goto l61
+				}
+				position++
+				if !rules[Rulereq_ws]() {
+					goto l61
+				}
+				if !rules[RuleLevel1]() {
+					goto l61
+				}
+				depth--
+				add(RuleSubtraction, position62)
+			}
+			return true
+		l61:
+			position, tokenIndex, depth = position61, tokenIndex61, depth61
+			return false
+		},
+		/* 19 Concatenation <- <((' ' / '\t' / '\n' / '\r')+ Level1)> */
+		func() bool {
+			position63, tokenIndex63, depth63 := position, tokenIndex, depth
+			{
+				position64 := position
+				depth++
+				{
+					position67, tokenIndex67, depth67 := position, tokenIndex, depth
+					if buffer[position] != ' ' {
+						goto l68
+					}
+					position++
+					goto l67
+				l68:
+					position, tokenIndex, depth = position67, tokenIndex67, depth67
+					if buffer[position] != '\t' {
+						goto l69
+					}
+					position++
+					goto l67
+				l69:
+					position, tokenIndex, depth = position67, tokenIndex67, depth67
+					if buffer[position] != '\n' {
+						goto l70
+					}
+					position++
+					goto l67
+				l70:
+					position, tokenIndex, depth = position67, tokenIndex67, depth67
+					if buffer[position] != '\r' {
+						goto l63
+					}
+					position++
+				}
+			l67:
+			l65:
+				{
+					position66, tokenIndex66, depth66 := position, tokenIndex, depth
+					{
+						position71, tokenIndex71, depth71 := position, tokenIndex, depth
+						if buffer[position] != ' ' {
+							goto l72
+						}
+						position++
+						goto l71
+					l72:
+						position, tokenIndex, depth = position71, tokenIndex71, depth71
+						if buffer[position] != '\t' {
+							goto l73
+						}
+						position++
+						goto l71
+					l73:
+						position, tokenIndex, depth = position71, tokenIndex71, depth71
+						if buffer[position] != '\n' {
+							goto l74
+						}
+						position++
+						goto l71
+					l74:
+						position, tokenIndex, depth = position71, tokenIndex71, depth71
+						if buffer[position] != '\r' {
+							goto l66
+						}
+						position++
+					}
+				l71:
+					goto l65
+				l66:
+					position, tokenIndex, depth = position66, tokenIndex66, depth66
+				}
+				if !rules[RuleLevel1]() {
+					goto l63
+				}
+				depth--
+				add(RuleConcatenation, position64)
+			}
+			return true
+		l63:
+			position, tokenIndex, depth = position63, tokenIndex63, depth63
+			return false
+		},
+		/* 20 Level1 <- <(Level0 (req_ws (Multiplication / Division / Modulo))*)> */
+		func() bool {
+			position75, tokenIndex75, depth75 := position, tokenIndex, depth
+			{
+				position76 := position
+				depth++
+				if !rules[RuleLevel0]() {
+					goto l75
+				}
+			l77:
+				{
+					position78, tokenIndex78, depth78 := position, tokenIndex, depth
+					if !rules[Rulereq_ws]() {
+						goto l78
+					}
+					{
+						position79, tokenIndex79, depth79 := position, tokenIndex, depth
+						if !rules[RuleMultiplication]() {
+							goto l80
+						}
+						goto l79
+					l80:
+						position, tokenIndex, depth = position79, tokenIndex79, depth79
+						if !rules[RuleDivision]() {
+							goto l81
+						}
+						goto l79
+					l81:
+						position, tokenIndex, depth = position79, tokenIndex79, depth79
+						if !rules[RuleModulo]() {
+							goto l78
+						}
+					}
+				l79:
+					goto l77
+				l78:
+					position, tokenIndex, depth = position78, tokenIndex78, depth78
+				}
+				depth--
+				add(RuleLevel1, position76)
+			}
+			return true
+		l75:
+			position, tokenIndex, depth = position75, tokenIndex75, depth75
+			return false
+		},
+		/* 21 Multiplication <- <('*' req_ws Level0)> */
+		func() bool {
+			position82, tokenIndex82, depth82 := position, tokenIndex, depth
+			{
+				position83 := position
+				depth++
+				if buffer[position] != '*' {
+					goto l82
+				}
+				position++
+				if !rules[Rulereq_ws]() {
+					goto l82
+				}
+				if !rules[RuleLevel0]() {
+					goto l82
+				}
+				depth--
+				add(RuleMultiplication, position83)
+			}
+			return true
+		l82:
+			position, tokenIndex, depth = position82, tokenIndex82, depth82
+			return false
+		},
+		/* 22 Division <- <('/' req_ws Level0)> */
+		func() bool {
+			position84, tokenIndex84, depth84 := position, tokenIndex, depth
+			{
+				position85 := position
+				depth++
+				if buffer[position] != '/' {
+					goto l84
+				}
+				position++
+				if !rules[Rulereq_ws]() {
+					goto l84
+				}
+				if !rules[RuleLevel0]() {
+					goto l84
+				}
+				depth--
+				add(RuleDivision, position85)
+			}
+			return true
+		l84:
+			position, tokenIndex, depth = position84, tokenIndex84, depth84
+			return false
+		},
+		/* 23 Modulo <- <('%' req_ws Level0)> */
+		func() bool {
+			position86, tokenIndex86, depth86 := position, tokenIndex, depth
+			{
+				position87 := position
+				depth++
+				if buffer[position] != '%' {
+					goto l86
+				}
+				position++
+				if !rules[Rulereq_ws]() {
+					goto l86
+				}
+				if !rules[RuleLevel0]() {
+					goto l86
+				}
+				depth--
+				add(RuleModulo, position87)
+			}
+			return true
+		l86:
+			position, tokenIndex, depth = position86, tokenIndex86, depth86
+			return false
+		},
+		/* 24 Level0 <- <(Grouped / Not / Call / Boolean / Nil / String / Float / Integer / List / Map / Merge / Auto / Delete / Reference)> */
+		func() bool {
+			position88, tokenIndex88, depth88 := position, tokenIndex, depth
+			{
+				position89 := position
+				depth++
+				{
+					position90, tokenIndex90, depth90 := position, tokenIndex, depth
+					if !rules[RuleGrouped]() {
+						goto l91
+					}
+					goto l90
+				l91:
+					position, tokenIndex, depth = position90, tokenIndex90, depth90
+					if !rules[RuleNot]() {
+						goto l92
+					}
+					goto l90
+				l92:
+					position, tokenIndex, depth = position90, tokenIndex90, depth90
+					if !rules[RuleCall]() {
+						goto l93
+					}
+					goto l90
+				l93:
+					position, tokenIndex, depth = position90, tokenIndex90, depth90
+					if !rules[RuleBoolean]() {
+						goto l94
+					}
+					goto l90
+				l94:
+					position, tokenIndex, depth = position90, tokenIndex90, depth90
+					if !rules[RuleNil]() {
+						goto l95
+					}
+					goto l90
+				l95:
+					position, tokenIndex, depth = position90, tokenIndex90, depth90
+					if !rules[RuleString]() {
+						goto l96
+					}
+					goto l90
+				l96:
+					position, tokenIndex, depth = position90, tokenIndex90, depth90
+					if !rules[RuleFloat]() {
+						goto l97
+					}
+					goto l90
+				l97:
+					position, tokenIndex, depth = position90, tokenIndex90, depth90
+					if !rules[RuleInteger]() {
+						goto l98
+					}
+					goto l90
+				l98:
+					position, tokenIndex, depth = position90, tokenIndex90, depth90
+					if !rules[RuleList]() {
+						goto l99
+					}
+					goto l90
+				l99:
+					position, tokenIndex, depth = position90, tokenIndex90, depth90
+					if !rules[RuleMap]() {
+						goto l100
+					}
+					goto l90
+				l100:
+					position, tokenIndex, depth = position90, tokenIndex90, depth90
+					if !rules[RuleMerge]() {
+						goto l101
+					}
+					goto l90
+				l101:
+					position, tokenIndex, depth = position90, tokenIndex90, depth90
+					if !rules[RuleAuto]() {
+						goto l102
+					}
+					goto l90
+				l102:
+					position, tokenIndex, depth = position90, tokenIndex90, depth90
+					if !rules[RuleDelete]() {
+						goto l103
+					}
+					goto l90
+				l103:
+					position, tokenIndex, depth = position90, tokenIndex90, depth90
+					if !rules[RuleReference]() {
+						goto l88
+					}
+				}
+			l90:
+				depth--
+				add(RuleLevel0, position89)
+			}
+			return true
+		l88:
+			position, tokenIndex, depth = position88, tokenIndex88, depth88
+			return false
+		},
+		/* 25 Grouped <- <('(' Expression ')')> */
+		func() bool {
+			position104, tokenIndex104, depth104 := position, tokenIndex, depth
+			{
+				position105 := position
+				depth++
+				if buffer[position] != '(' {
+					goto l104
+				}
+				position++
+				if !rules[RuleExpression]() {
+					goto l104
+				}
+				if buffer[position] != ')' {
+					goto l104
+				}
+				position++
+				depth--
+				add(RuleGrouped, position105)
+			}
+			return true
+		l104:
+			position, tokenIndex, depth = position104, tokenIndex104, depth104
+			return false
+		},
+		/* 26 Not <- <('!' ws Level0)> */
+		func() bool {
+			position106, tokenIndex106, depth106 := position, tokenIndex, depth
+			{
+				position107 := position
+				depth++
+				if buffer[position] != '!' {
+					goto l106
+				}
+				position++
+				if !rules[Rulews]() {
+					goto l106
+				}
+				if !rules[RuleLevel0]() {
+					goto l106
+				}
+				depth--
+				add(RuleNot, position107)
+			}
+			return true
+		l106:
+			position, tokenIndex, depth = position106, tokenIndex106, depth106
+			return false
+		},
+		/* 27 Call <- <(Name (('(' Arguments ')') / ('[' ws Projection ws ']')))> */
+		func() bool {
+			position108, tokenIndex108, depth108 := position, tokenIndex, depth
+			{
+				position109 := position
+				depth++
+				if !rules[RuleName]() {
+					goto l108
+				}
+				{
+					position110, tokenIndex110, depth110 := position, tokenIndex, depth
+					if buffer[position] != '(' {
+						goto l111
+					}
+					position++
+					if !rules[RuleArguments]() {
+						goto l111
+					}
+					if buffer[position] != ')' {
+						goto l111
+					}
+					position++
+					goto l110
+				l111:
+					position, tokenIndex, depth = position110, tokenIndex110, depth110
+					if buffer[position] != '[' {
+						goto l108
+					}
+					position++
+					if !rules[Rulews]() {
+						goto l108
+					}
+					if !rules[RuleProjection]() {
+						goto l108
+					}
+					if !rules[Rulews]() {
+						goto l108
+					}
+					if buffer[position] != ']' {
+						goto l108
+					}
+					position++
+				}
+			l110:
+				depth--
+				add(RuleCall, position109)
+			}
+			return true
+		l108:
+			position, tokenIndex, depth = position108, tokenIndex108, depth108
+			return false
+		},
+		/* 28 Arguments <- <(Argument (Comma ws Argument)*)> */
+		func() bool {
+			position112, tokenIndex112, depth112 := position, tokenIndex, depth
+			{
+				position113 := position
+				depth++
+				if !rules[RuleArgument]() {
+					goto l112
+				}
+			l114:
+				{
+					position115, tokenIndex115, depth115 := position, tokenIndex, depth
+					if !rules[RuleComma]() {
+						goto l115
+					}
+					if !rules[Rulews]() {
+						goto l115
+					}
+					if !rules[RuleArgument]() {
+						goto l115
+					}
+					goto l114
+				l115:
+					position, tokenIndex, depth = position115, tokenIndex115, depth115
+				}
+				depth--
+				add(RuleArguments, position113)
+			}
+			return true
+		l112:
+			position, tokenIndex, depth = position112, tokenIndex112, depth112
+			return false
+		},
+		/* 29 Argument <- <(Lambda / Expression)> */
+		func() bool {
+			position116, tokenIndex116, depth116 := position, tokenIndex, depth
+			{
+				position117 := position
+				depth++
+				{
+					position118, tokenIndex118, depth118 := position, tokenIndex, depth
+					if !rules[RuleLambda]() {
+						goto l119
+					}
+					goto l118
+				l119:
+					position, tokenIndex, depth = position118, tokenIndex118, depth118
+					if !rules[RuleExpression]() {
+						goto l116
+					}
+				}
+			l118:
+				depth--
+				add(RuleArgument, position117)
+			}
+			return true
+		l116:
+			position, tokenIndex, depth = position116, tokenIndex116, depth116
+			return false
+		},
+		/* 30 Projection <- <(Expression ws Lambda)> */
+		func() bool {
+			position120, tokenIndex120, depth120 := position, tokenIndex, depth
+			{
+				position121 := position
+				depth++
+				if !rules[RuleExpression]() {
+					goto l120
+				}
+				if !rules[Rulews]() {
+					goto l120
+				}
+				if !rules[RuleLambda]() {
+					goto l120
+				}
+				depth--
+				add(RuleProjection, position121)
+			}
+			return true
+		l120:
+			position, tokenIndex, depth = position120, tokenIndex120, depth120
+			return false
+		},
+		/* 31 Name <- <([a-z] / [A-Z] / [0-9] / '_')+> */
+		func() bool {
+			position122, tokenIndex122, depth122 := position, tokenIndex, depth
+			{
+				position123 := position
+				depth++
+				{
+					position126, tokenIndex126, depth126 := position, tokenIndex, depth
+					if c := buffer[position]; c < 'a' || c > 'z' {
+						goto l127
+					}
+					position++
+					goto l126
+				l127:
+					position, tokenIndex, depth = position126, tokenIndex126, depth126
+					if c := buffer[position]; c < 'A' || c > 'Z' {
+						goto l128
+					}
+					position++
+					goto l126
+				l128:
+					position, tokenIndex, depth = position126, tokenIndex126, depth126
+					if c := buffer[position]; c < '0' || c > '9' {
+						goto l129
+					}
+					position++
+					goto l126
+				l129:
+					position, tokenIndex, depth = position126, tokenIndex126, depth126
+					if buffer[position] != '_' {
+						goto l122
+					}
+					position++
+				}
+			l126:
+			l124:
+				{
+					position125, tokenIndex125, depth125 := position, tokenIndex, depth
+					{
+						position130, tokenIndex130, depth130 := position, tokenIndex, depth
+						if c := buffer[position]; c < 'a' || c > 'z' {
+							goto l131
+						}
+						position++
+						goto l130
+					l131:
+						position, tokenIndex, depth = position130, tokenIndex130, depth130
+						if c := buffer[position]; c < 'A' || c > 'Z' {
+							goto l132
+						}
+						position++
+						goto l130
+					l132:
+						position, tokenIndex, depth = position130, tokenIndex130, depth130
+						if c := buffer[position]; c < '0' || c > '9' {
+							goto l133
+						}
+						position++
+						goto l130
+					l133:
+						position, tokenIndex, depth = position130, tokenIndex130, depth130
+						if buffer[position] != '_' {
+							goto l125
+						}
+						position++
+					}
+				l130:
+					goto l124
+				l125:
+					position, tokenIndex, depth = position125, tokenIndex125, depth125
+				}
+				depth--
+				add(RuleName, position123)
+			}
+			return true
+		l122:
+			position, tokenIndex, depth = position122, tokenIndex122, depth122
+			return false
+		},
+		/* 32 Lambda <- <('|' ws Parameters ws '|' ws ('-' '>') ws Expression)> */
+		func() bool {
+			position134, tokenIndex134, depth134 := position, tokenIndex, depth
+			{
+				position135 := position
+				depth++
+				if buffer[position] != '|' {
+					goto l134
+				}
+				position++
+				if !rules[Rulews]() {
+					goto l134
+				}
+				if !rules[RuleParameters]() {
+					goto l134
+				}
+				if !rules[Rulews]() {
+					goto l134
+				}
+				if buffer[position] != '|' {
+					goto l134
+				}
+				position++
+				if !rules[Rulews]() {
+					goto l134
+				}
+				if buffer[position] != '-' {
+					goto l134
+				}
+				position++
+				if buffer[position] != '>' {
+					goto l134
+				}
+				position++
+				if !rules[Rulews]() {
+					goto l134
+				}
+				if !rules[RuleExpression]() {
+					goto l134
+				}
+				depth--
+				add(RuleLambda, position135)
+			}
+			return true
+		l134:
+			position, tokenIndex, depth = position134, tokenIndex134, depth134
+			return false
+		},
+		/* 33 Parameters <- <(Parameter (ws ',' ws Parameter)*)> */
+		func() bool {
+			position136, tokenIndex136, depth136 := position, tokenIndex, depth
+			{
+				position137 := position
+				depth++
+				if !rules[RuleParameter]() {
+					goto l136
+				}
+			l138:
+				{
+					position139, tokenIndex139, depth139 := position, tokenIndex, depth
+					if !rules[Rulews]() {
+						goto l139
+					}
+					if buffer[position] != ',' {
+						goto l139
+					}
+					position++
+					if !rules[Rulews]() {
+						goto l139
+					}
+					if !rules[RuleParameter]() {
+						goto l139
+					}
+					goto l138
+				l139:
+					position, tokenIndex, depth = position139, tokenIndex139, depth139
+				}
+				depth--
+				add(RuleParameters, position137)
+			}
+			return true
+		l136:
+			position, tokenIndex, depth = position136, tokenIndex136, depth136
+			return false
+		},
+		/* 34 Parameter <- <(([a-z] / [A-Z] / '_') ([a-z] / [A-Z] / [0-9] / '_')*)> */
+		func() bool {
+			position140, tokenIndex140, depth140 := position, tokenIndex, depth
+			{
+				position141 := position
+				depth++
+				{
+					position142, tokenIndex142, depth142 := position, tokenIndex, depth
+					if c := buffer[position]; c < 'a' || c > 'z' {
+						goto l143
+					}
+					position++
+					goto l142
+				l143:
+					position, tokenIndex, depth = position142, tokenIndex142, depth142
+					if c := buffer[position]; c < 'A' || c > 'Z' {
+						goto l144
+					}
+					position++
+					goto l142
+				l144:
+					position, tokenIndex, depth = position142, tokenIndex142, depth142
+					if buffer[position] != '_' {
+						goto l140
+					}
+					position++
+				}
+			l142:
+			l145:
+				{
+					position146, tokenIndex146, depth146 := position, tokenIndex, depth
+					{
+						position147, tokenIndex147, depth147 := position, tokenIndex, depth
+						if c := buffer[position]; c < 'a' || c > 'z' {
+							goto l148
+						}
+						position++
+						goto l147
+					l148:
+						position, tokenIndex, depth = position147, tokenIndex147, depth147
+						if c := buffer[position]; c < 'A' || c > 'Z' {
+							goto l149
+						}
+						position++
+						goto l147
+					l149:
+						position, tokenIndex, depth = position147, tokenIndex147, depth147
+						if c := buffer[position]; c < '0' || c > '9' {
+							goto l150
+						}
+						position++
+						goto l147
+					l150:
+						position, tokenIndex, depth = position147, tokenIndex147, depth147
+						if buffer[position] != '_' {
+							goto l146
+						}
+						position++
+					}
+				l147:
+					goto l145
+				l146:
+					position, tokenIndex, depth = position146, tokenIndex146, depth146
+				}
+				depth--
+				add(RuleParameter, position141)
+			}
+			return true
+		l140:
+			position, tokenIndex, depth = position140, tokenIndex140, depth140
+			return false
+		},
+		/* 35 Comma <- <','> */
+		func() bool {
+			position151, tokenIndex151, depth151 := position, tokenIndex, depth
+			{
+				position152 := position
+				depth++
+				if buffer[position] != ',' {
+					goto l151
+				}
+				position++
+				depth--
+				add(RuleComma, position152)
+			}
+			return true
+		l151:
+			position, tokenIndex, depth = position151, tokenIndex151, depth151
+			return false
+		},
+		/* 36 Float <- <('-'? [0-9]+ '.' [0-9]+ (('e' / 'E') ('-' / '+')? [0-9]+)?)> */
+		func() bool {
+			position153, tokenIndex153, depth153 := position, tokenIndex, depth
+			{
+				position154 := position
+				depth++
+				{
+					position155, tokenIndex155, depth155 := position, tokenIndex, depth
+					if buffer[position] != '-' {
+						goto l155
+					}
+					position++
+					goto l156
+				l155:
+					position, tokenIndex, depth = position155, tokenIndex155, depth155
+				}
+			l156:
+				if c := buffer[position]; c < '0' || c > '9' {
+					goto l153
+				}
+				position++
+			l157:
+				{
+					position158, tokenIndex158, depth158 := position, tokenIndex, depth
+					if c := buffer[position]; c < '0' || c > '9' {
+						goto l158
+					}
+					position++
+					goto l157
+				l158:
+					position, tokenIndex, depth = position158, tokenIndex158, depth158
+				}
+				if buffer[position] != '.' {
+					goto l153
+				}
+				position++
+				if c := buffer[position]; c < '0' || c > '9' {
+					goto l153
+				}
+				position++
+			l159:
+				{
+					position160, tokenIndex160, depth160 := position, tokenIndex, depth
+					if c := buffer[position]; c < '0' || c > '9' {
+						goto l160
+					}
+					position++
+					goto l159
+				l160:
+					position, tokenIndex, depth = position160, tokenIndex160, depth160
+				}
+				{
+					position161, tokenIndex161, depth161 := position, tokenIndex, depth
+					{
+						position163, tokenIndex163, depth163 := position, tokenIndex, depth
+						if buffer[position] != 'e' {
+							goto l164
+						}
+						position++
+						goto l163
+					l164:
+						position, tokenIndex, depth = position163, tokenIndex163, depth163
+						if buffer[position] != 'E' {
+							goto l161
+						}
+						position++
+					}
+				l163:
+					{
+						position165, tokenIndex165, depth165 := position, tokenIndex, depth
+						{
+							position167, tokenIndex167, depth167 := position, tokenIndex, depth
+							if buffer[position] != '-' {
+								goto l168
+							}
+							position++
+							goto l167
+						l168:
+							position, tokenIndex, depth = position167, tokenIndex167, depth167
+							if buffer[position] != '+' {
+								goto l165
+							}
+							position++
+						}
+					l167:
+						goto l166
+					l165:
+						position, tokenIndex, depth = position165, tokenIndex165, depth165
+					}
+				l166:
+					if c := buffer[position]; c < '0' || c > '9' {
+						goto l161
+					}
+					position++
+				l169:
+					{
+						position170, tokenIndex170, depth170 := position, tokenIndex, depth
+						if c := buffer[position]; c < '0' || c > '9' {
+							goto l170
+						}
+						position++
+						goto l169
+					l170:
+						position, tokenIndex, depth = position170, tokenIndex170, depth170
+					}
+					goto l162
+				l161:
+					position, tokenIndex, depth = position161, tokenIndex161, depth161
+				}
+			l162:
+				depth--
+				add(RuleFloat, position154)
+			}
+			return true
+		l153:
+			position, tokenIndex, depth = position153, tokenIndex153, depth153
+			return false
+		},
+		/* 37 Integer <- <('-'? ([0-9] / '_')+)> */
+		func() bool {
+			position171, tokenIndex171, depth171 := position, tokenIndex, depth
+			{
+				position172 := position
+				depth++
+				{
+					position173, tokenIndex173, depth173 := position, tokenIndex, depth
+					if buffer[position] != '-' {
+						goto l173
+					}
+					position++
+					goto l174
+				l173:
+					position, tokenIndex, depth = position173, tokenIndex173, depth173
+				}
+			l174:
+				{
+					position177, tokenIndex177, depth177 := position, tokenIndex, depth
+					if c := buffer[position]; c < '0' || c > '9' {
+						goto l178
+					}
+					position++
+					goto l177
+				l178:
+					position, tokenIndex, depth = position177, tokenIndex177, depth177
+					if buffer[position] != '_' {
+						goto l171
+					}
+					position++
+				}
+			l177:
+			l175:
+				{
+					position176, tokenIndex176, depth176 := position, tokenIndex, depth
+					{
+						position179, tokenIndex179, depth179 := position, tokenIndex, depth
+						if c := buffer[position]; c < '0' || c > '9' {
+							goto l180
+						}
+						position++
+						goto l179
+					l180:
+						position, tokenIndex, depth = position179, tokenIndex179, depth179
+						if buffer[position] != '_' {
+							goto l176
+						}
+						position++
+					}
+				l179:
+					goto l175
+				l176:
+					position, tokenIndex, depth = position176, tokenIndex176, depth176
+				}
+				depth--
+				add(RuleInteger, position172)
+			}
+			return true
+		l171:
+			position, tokenIndex, depth = position171, tokenIndex171, depth171
+			return false
+		},
+		/* 38 String <- <('"' (('\\' '"') / (!'"' .))* '"')> */
+		func() bool {
+			position181, tokenIndex181, depth181 := position, tokenIndex, depth
+			{
+				position182 := position
+				depth++
+				if buffer[position] != '"' {
+					goto l181
+				}
+				position++
+			l183:
+				{
+					position184, tokenIndex184, depth184 := position, tokenIndex, depth
+					{
+						position185, tokenIndex185, depth185 := position, tokenIndex, depth
+						if buffer[position] != '\\' {
+							goto l186
+						}
+						position++
+						if buffer[position] != '"' {
+							goto l186
+						}
+						position++
+						goto l185
+					l186:
+						position, tokenIndex, depth = position185, tokenIndex185, depth185
+						{
+							position187, tokenIndex187, depth187 := position, tokenIndex, depth
+							if buffer[position] != '"' {
+								goto l187
+							}
+							position++
+							goto l184
+						l187:
+							position, tokenIndex, depth = position187, tokenIndex187, depth187
+						}
+						if !matchDot() {
+							goto l184
+						}
+					}
+				l185:
+					goto l183
+				l184:
+					position, tokenIndex, depth = position184, tokenIndex184, depth184
+				}
+				if buffer[position] != '"' {
+					goto l181
+				}
+				position++
+				depth--
+				add(RuleString, position182)
+			}
+			return true
+		l181:
+			position, tokenIndex, depth = position181, tokenIndex181, depth181
+			return false
+		},
+		/* 39 Boolean <- <(('t' 'r' 'u' 'e') / ('f' 'a' 'l' 's' 'e'))> */
+		func() bool {
+			position188, tokenIndex188, depth188 := position, tokenIndex, depth
+			{
+				position189 := position
+				depth++
+				{
+					position190, tokenIndex190, depth190 := position, tokenIndex, depth
+					if buffer[position] != 't' {
+						goto l191
+					}
+					position++
+					if buffer[position] != 'r' {
+						goto l191
+					}
+					position++
+					if buffer[position] != 'u' {
+						goto l191
+					}
+					position++
+					if buffer[position] != 'e' {
+						goto l191
+					}
+					position++
+					goto l190
+				l191:
+					position, tokenIndex, depth = position190, tokenIndex190, depth190
+					if buffer[position] != 'f' {
+						goto l188
+					}
+					position++
+					if buffer[position] != 'a' {
+						goto l188
+					}
+					position++
+					if buffer[position] != 'l' {
+						goto l188
+					}
+					position++
+					if buffer[position] != 's' {
+						goto l188
+					}
+					position++
+					if buffer[position] != 'e' {
+						goto l188
+					}
+					position++
+				}
+			l190:
+				depth--
+				add(RuleBoolean, position189)
+			}
+			return true
+		l188:
+			position, tokenIndex, depth = position188, tokenIndex188, depth188
+			return false
+		},
+		/* 40 Nil <- <('n' 'i' 'l')> */
+		func() bool {
+			position192, tokenIndex192, depth192 := position, tokenIndex, depth
+			{
+				position193 := position
+				depth++
+				if buffer[position] != 'n' {
+					goto l192
+				}
+				position++
+				if buffer[position] != 'i' {
+					goto l192
+				}
+				position++
+				if buffer[position] != 'l' {
+					goto l192
+				}
+				position++
+				depth--
+				add(RuleNil, position193)
+			}
+			return true
+		l192:
+			position, tokenIndex, depth = position192, tokenIndex192, depth192
+			return false
+		},
+		/* 41 List <- <(StartList Contents? ']')> */
+		func() bool {
+			position194, tokenIndex194, depth194 := position, tokenIndex, depth
+			{
+				position195 := position
+				depth++
+				if !rules[RuleStartList]() {
+					goto l194
+				}
+				{
+					position196, tokenIndex196, depth196 := position, tokenIndex, depth
+					if !rules[RuleContents]() {
+						goto l196
+					}
+					goto l197
+				l196:
+					position, tokenIndex, depth = position196, tokenIndex196, depth196
+				}
+			l197:
+				if buffer[position] != ']' {
+					goto l194
+				}
+				position++
+				depth--
+				add(RuleList, position195)
+			}
+			return true
+		l194:
+			position, tokenIndex, depth = position194, tokenIndex194, depth194
+			return false
+		},
+		/* 42 StartList <- <'['> */
+		func() bool {
+			position198, tokenIndex198, depth198 := position, tokenIndex, depth
+			{
+				position199 := position
+				depth++
+				if buffer[position] != '[' {
+					goto l198
+				}
+				position++
+				depth--
+				add(RuleStartList, position199)
+			}
+			return true
+		l198:
+			position, tokenIndex, depth = position198, tokenIndex198, depth198
+			return false
+		},
+		/* 43 Contents <- <(Expression (Comma ws Expression)*)> */
+		func() bool {
+			position200, tokenIndex200, depth200 := position, tokenIndex, depth
+			{
+				position201 := position
+				depth++
+				if !rules[RuleExpression]() {
+					goto l200
+				}
+			l202:
+				{
+					position203, tokenIndex203, depth203 := position, tokenIndex, depth
+					if !rules[RuleComma]() {
+						goto l203
+					}
+					if !rules[Rulews]() {
+						goto l203
+					}
+					if !rules[RuleExpression]() {
+						goto l203
+					}
+					goto l202
+				l203:
+					position, tokenIndex, depth = position203, tokenIndex203, depth203
+				}
+				depth--
+				add(RuleContents, position201)
+			}
+			return true
+		l200:
+			position, tokenIndex, depth = position200, tokenIndex200, depth200
+			return false
+		},
+		/* 44 Map <- <(StartMap ws Assignments? ws '}')> */
+		func() bool {
+			position204, tokenIndex204, depth204 := position, tokenIndex, depth
+			{
+				position205 := position
+				depth++
+				if !rules[RuleStartMap]() {
+					goto l204
+				}
+				if !rules[Rulews]() {
+					goto l204
+				}
 				{
-					position52, tokenIndex52, depth52 := position, tokenIndex, depth
-					if !rules[RuleComma]() {
-						goto l52
+					position206, tokenIndex206, depth206 := position, tokenIndex, depth
+					if !rules[RuleAssignments]() {
+						goto l206
+					}
+					goto l207
+				l206:
+					position, tokenIndex, depth = position206, tokenIndex206, depth206
+				}
+			l207:
+				if !rules[Rulews]() {
+					goto l204
+				}
+				if buffer[position] != '}' {
+					goto l204
+				}
+				position++
+				depth--
+				add(RuleMap, position205)
+			}
+			return true
+		l204:
+			position, tokenIndex, depth = position204, tokenIndex204, depth204
+			return false
+		},
+		/* 45 StartMap <- <'{'> */
+		func() bool {
+			position208, tokenIndex208, depth208 := position, tokenIndex, depth
+			{
+				position209 := position
+				depth++
+				if buffer[position] != '{' {
+					goto l208
+				}
+				position++
+				depth--
+				add(RuleStartMap, position209)
+			}
+			return true
+		l208:
+			position, tokenIndex, depth = position208, tokenIndex208, depth208
+			return false
+		},
+		/* 46 Assignments <- <(Assignment (ws ',' ws Assignment)*)> */
+		func() bool {
+			position210, tokenIndex210, depth210 := position, tokenIndex, depth
+			{
+				position211 := position
+				depth++
+				if !rules[RuleAssignment]() {
+					goto l210
+				}
+			l212:
+				{
+					position213, tokenIndex213, depth213 := position, tokenIndex, depth
+					if !rules[Rulews]() {
+						goto l213
+					}
+					if buffer[position] != ',' {
+						goto l213
 					}
+					position++
 					if !rules[Rulews]() {
-						goto l52
+						goto l213
 					}
-					if !rules[RuleExpression]() {
-						goto l52
+					if !rules[RuleAssignment]() {
+						goto l213
 					}
-					goto l51
-				l52:
-					position, tokenIndex, depth = position52, tokenIndex52, depth52
+					goto l212
+				l213:
+					position, tokenIndex, depth = position213, tokenIndex213, depth213
 				}
 				depth--
-				add(RuleArguments, position50)
+				add(RuleAssignments, position211)
 			}
 			return true
-		l49:
-			position, tokenIndex, depth = position49, tokenIndex49, depth49
+		l210:
+			position, tokenIndex, depth = position210, tokenIndex210, depth210
+			return false
+		},
+		/* 47 Assignment <- <(Key ws ':' ws Expression)> */
+		func() bool {
+			position214, tokenIndex214, depth214 := position, tokenIndex, depth
+			{
+				position215 := position
+				depth++
+				if !rules[RuleKey]() {
+					goto l214
+				}
+				if !rules[Rulews]() {
+					goto l214
+				}
+				if buffer[position] != ':' {
+					goto l214
+				}
+				position++
+				if !rules[Rulews]() {
+					goto l214
+				}
+				if !rules[RuleExpression]() {
+					goto l214
+				}
+				depth--
+				add(RuleAssignment, position215)
+			}
+			return true
+		l214:
+			position, tokenIndex, depth = position214, tokenIndex214, depth214
 			return false
 		},
-		/* 12 Name <- <([a-z] / [A-Z] / [0-9] / '_')+> */
+		/* 48 Key <- <(String / (([a-z] / [A-Z] / [0-9] / '_') ([a-z] / [A-Z] / [0-9] / '_' / '-')*))> */
 		func() bool {
-			position53, tokenIndex53, depth53 := position, tokenIndex, depth
+			position216, tokenIndex216, depth216 := position, tokenIndex, depth
 			{
-				position54 := position
+				position217 := position
 				depth++
 				{
-					position57, tokenIndex57, depth57 := position, tokenIndex, depth
-					if c := buffer[position]; c < 'a' || c > 'z' {
-						goto l58
+					position218, tokenIndex218, depth218 := position, tokenIndex, depth
+					if !rules[RuleString]() {
+						goto l219
 					}
-					position++
-					goto l57
-				l58:
-					position, tokenIndex, depth = position57, tokenIndex57, depth57
-					if c := buffer[position]; c < 'A' || c > 'Z' {
-						goto l59
+					goto l218
+				l219:
+					position, tokenIndex, depth = position218, tokenIndex218, depth218
+					{
+						position220, tokenIndex220, depth220 := position, tokenIndex, depth
+						if c := buffer[position]; c < 'a' || c > 'z' {
+							goto l221
+						}
+						position++
+						goto l220
+					l221:
+						position, tokenIndex, depth = position220, tokenIndex220, depth220
+						if c := buffer[position]; c < 'A' || c > 'Z' {
+							goto l222
+						}
+						position++
+						goto l220
+					l222:
+						position, tokenIndex, depth = position220, tokenIndex220, depth220
+						if c := buffer[position]; c < '0' || c > '9' {
+							goto l223
+						}
+						position++
+						goto l220
+					l223:
+						position, tokenIndex, depth = position220, tokenIndex220, depth220
+						if buffer[position] != '_' {
+							goto l216
+						}
+						position++
 					}
-					position++
-					goto l57
-				l59:
-					position, tokenIndex, depth = position57, tokenIndex57, depth57
-					if c := buffer[position]; c < '0' || c > '9' {
-						goto l60
+				l220:
+				l224:
+					{
+						position225, tokenIndex225, depth225 := position, tokenIndex, depth
+						{
+							position226, tokenIndex226, depth226 := position, tokenIndex, depth
+							if c := buffer[position]; c < 'a' || c > 'z' {
+								goto l227
+							}
+							position++
+							goto l226
+						l227:
+							position, tokenIndex, depth = position226, tokenIndex226, depth226
+							if c := buffer[position]; c < 'A' || c > 'Z' {
+								goto l228
+							}
+							position++
+							goto l226
+						l228:
+							position, tokenIndex, depth = position226, tokenIndex226, depth226
+							if c := buffer[position]; c < '0' || c > '9' {
+								goto l229
+							}
+							position++
+							goto l226
+						l229:
+							position, tokenIndex, depth = position226, tokenIndex226, depth226
+							if buffer[position] != '_' {
+								goto l230
+							}
+							position++
+							goto l226
+						l230:
+							position, tokenIndex, depth = position226, tokenIndex226, depth226
+							if buffer[position] != '-' {
+								goto l225
+							}
+							position++
+						}
+					l226:
+						goto l224
+					l225:
+						position, tokenIndex, depth = position225, tokenIndex225, depth225
 					}
-					position++
-					goto l57
-				l60:
-					position, tokenIndex, depth = position57, tokenIndex57, depth57
-					if buffer[position] != '_' {
-						goto l53
+				}
+			l218:
+				depth--
+				add(RuleKey, position217)
+			}
+			return true
+		l216:
+			position, tokenIndex, depth = position216, tokenIndex216, depth216
+			return false
+		},
+		/* 49 Merge <- <(('m' 'e' 'r' 'g' 'e') ((req_ws MergeNone) / ((req_ws MergeReplace)? (req_ws MergeOn)? (req_ws MergePath)?)))> */
+		func() bool {
+			position231, tokenIndex231, depth231 := position, tokenIndex, depth
+			{
+				position232 := position
+				depth++
+				if buffer[position] != 'm' {
+					goto l231
+				}
+				position++
+				if buffer[position] != 'e' {
+					goto l231
+				}
+				position++
+				if buffer[position] != 'r' {
+					goto l231
+				}
+				position++
+				if buffer[position] != 'g' {
+					goto l231
+				}
+				position++
+				if buffer[position] != 'e' {
+					goto l231
+				}
+				position++
+				{
+					position233, tokenIndex233, depth233 := position, tokenIndex, depth
+					if !rules[Rulereq_ws]() {
+						goto l234
 					}
-					position++
+					if !rules[RuleMergeNone]() {
+						goto l234
+					}
+					goto l233
+				l234:
+					position, tokenIndex, depth = position233, tokenIndex233, depth233
+					{
+						position235, tokenIndex235, depth235 := position, tokenIndex, depth
+						if !rules[Rulereq_ws]() {
+							goto l235
+						}
+						if !rules[RuleMergeReplace]() {
+							goto l235
+						}
+						goto l236
+					l235:
+						position, tokenIndex, depth = position235, tokenIndex235, depth235
+					}
+				l236:
+					{
+						position237, tokenIndex237, depth237 := position, tokenIndex, depth
+						if !rules[Rulereq_ws]() {
+							goto l237
+						}
+						if !rules[RuleMergeOn]() {
+							goto l237
+						}
+						goto l238
+					l237:
+						position, tokenIndex, depth = position237, tokenIndex237, depth237
+					}
+				l238:
+					{
+						position239, tokenIndex239, depth239 := position, tokenIndex, depth
+						if !rules[Rulereq_ws]() {
+							goto l239
+						}
+						if !rules[RuleMergePath]() {
+							goto l239
+						}
+						goto l240
+					l239:
+						position, tokenIndex, depth = position239, tokenIndex239, depth239
+					}
+				l240:
+				}
+			l233:
+				depth--
+				add(RuleMerge, position232)
+			}
+			return true
+		l231:
+			position, tokenIndex, depth = position231, tokenIndex231, depth231
+			return false
+		},
+		/* 50 MergeNone <- <(('n' 'o' 'n' 'e') !([a-z] / [A-Z] / [0-9] / '_' / '-' / '.'))> */
+		func() bool {
+			position241, tokenIndex241, depth241 := position, tokenIndex, depth
+			{
+				position242 := position
+				depth++
+				if buffer[position] != 'n' {
+					goto l241
+				}
+				position++
+				if buffer[position] != 'o' {
+					goto l241
+				}
+				position++
+				if buffer[position] != 'n' {
+					goto l241
+				}
+				position++
+				if buffer[position] != 'e' {
+					goto l241
 				}
-			l57:
-			l55:
+				position++
 				{
-					position56, tokenIndex56, depth56 := position, tokenIndex, depth
+					position243, tokenIndex243, depth243 := position, tokenIndex, depth
 					{
-						position61, tokenIndex61, depth61 := position, tokenIndex, depth
+						position244, tokenIndex244, depth244 := position, tokenIndex, depth
 						if c := buffer[position]; c < 'a' || c > 'z' {
-							goto l62
+							goto l245
 						}
 						position++
-						goto l61
-					l62:
-						position, tokenIndex, depth = position61, tokenIndex61, depth61
+						goto l244
+					l245:
+						position, tokenIndex, depth = position244, tokenIndex244, depth244
 						if c := buffer[position]; c < 'A' || c > 'Z' {
-							goto l63
+							goto l246
 						}
 						position++
-						goto l61
-					l63:
-						position, tokenIndex, depth = position61, tokenIndex61, depth61
+						goto l244
+					l246:
+						position, tokenIndex, depth = position244, tokenIndex244, depth244
 						if c := buffer[position]; c < '0' || c > '9' {
-							goto l64
+							goto l247
 						}
 						position++
-						goto l61
-					l64:
-						position, tokenIndex, depth = position61, tokenIndex61, depth61
+						goto l244
+					l247:
+						position, tokenIndex, depth = position244, tokenIndex244, depth244
 						if buffer[position] != '_' {
-							goto l56
+							goto l248
+						}
+						position++
+						goto l244
+					l248:
+						position, tokenIndex, depth = position244, tokenIndex244, depth244
+						if buffer[position] != '-' {
+							goto l249
+						}
+						position++
+						goto l244
+					l249:
+						position, tokenIndex, depth = position244, tokenIndex244, depth244
+						if buffer[position] != '.' {
+							goto l243
 						}
 						position++
 					}
-				l61:
-					goto l55
-				l56:
-					position, tokenIndex, depth = position56, tokenIndex56, depth56
+				l244:
+					goto l241
+				l243:
+					position, tokenIndex, depth = position243, tokenIndex243, depth243
 				}
 				depth--
-				add(RuleName, position54)
+				add(RuleMergeNone, position242)
 			}
 			return true
-		l53:
-			position, tokenIndex, depth = position53, tokenIndex53, depth53
+		l241:
+			position, tokenIndex, depth = position241, tokenIndex241, depth241
 			return false
 		},
-		/* 13 Comma <- <','> */
+		/* 51 MergeReplace <- <(('r' 'e' 'p' 'l' 'a' 'c' 'e') !([a-z] / [A-Z] / [0-9] / '_' / '-' / '.'))> */
 		func() bool {
-			position65, tokenIndex65, depth65 := position, tokenIndex, depth
+			position250, tokenIndex250, depth250 := position, tokenIndex, depth
 			{
-				position66 := position
+				position251 := position
 				depth++
-				if buffer[position] != ',' {
-					goto l65
+				if buffer[position] != 'r' {
+					goto l250
+				}
+				position++
+				if buffer[position] != 'e' {
+					goto l250
+				}
+				position++
+				if buffer[position] != 'p' {
+					goto l250
+				}
+				position++
+				if buffer[position] != 'l' {
+					goto l250
+				}
+				position++
+				if buffer[position] != 'a' {
+					goto l250
+				}
+				position++
+				if buffer[position] != 'c' {
+					goto l250
+				}
+				position++
+				if buffer[position] != 'e' {
+					goto l250
+				}
+				position++
+				{
+					position252, tokenIndex252, depth252 := position, tokenIndex, depth
+					{
+						position253, tokenIndex253, depth253 := position, tokenIndex, depth
+						if c := buffer[position]; c < 'a' || c > 'z' {
+							goto l254
+						}
+						position++
+						goto l253
+					l254:
+						position, tokenIndex, depth = position253, tokenIndex253, depth253
+						if c := buffer[position]; c < 'A' || c > 'Z' {
+							goto l255
+						}
+						position++
+						goto l253
+					l255:
+						position, tokenIndex, depth = position253, tokenIndex253, depth253
+						if c := buffer[position]; c < '0' || c > '9' {
+							goto l256
+						}
+						position++
+						goto l253
+					l256:
+						position, tokenIndex, depth = position253, tokenIndex253, depth253
+						if buffer[position] != '_' {
+							goto l257
+						}
+						position++
+						goto l253
+					l257:
+						position, tokenIndex, depth = position253, tokenIndex253, depth253
+						if buffer[position] != '-' {
+							goto l258
+						}
+						position++
+						goto l253
+					l258:
+						position, tokenIndex, depth = position253, tokenIndex253, depth253
+						if buffer[position] != '.' {
+							goto l252
+						}
+						position++
+					}
+				l253:
+					goto l250
+				l252:
+					position, tokenIndex, depth = position252, tokenIndex252, depth252
+				}
+				depth--
+				add(RuleMergeReplace, position251)
+			}
+			return true
+		l250:
+			position, tokenIndex, depth = position250, tokenIndex250, depth250
+			return false
+		},
+		/* 52 MergeOn <- <(('o' 'n') req_ws MergeKey)> */
+		func() bool {
+			position259, tokenIndex259, depth259 := position, tokenIndex, depth
+			{
+				position260 := position
+				depth++
+				if buffer[position] != 'o' {
+					goto l259
+				}
+				position++
+				if buffer[position] != 'n' {
+					goto l259
+				}
+				position++
+				if !rules[Rulereq_ws]() {
+					goto l259
+				}
+				if !rules[RuleMergeKey]() {
+					goto l259
+				}
+				depth--
+				add(RuleMergeOn, position260)
+			}
+			return true
+		l259:
+			position, tokenIndex, depth = position259, tokenIndex259, depth259
+			return false
+		},
+		/* 53 MergeKey <- <(([a-z] / [A-Z] / [0-9] / '_') ([a-z] / [A-Z] / [0-9] / '_' / '-')*)> */
+		func() bool {
+			position261, tokenIndex261, depth261 := position, tokenIndex, depth
+			{
+				position262 := position
+				depth++
+				{
+					position263, tokenIndex263, depth263 := position, tokenIndex, depth
+					if c := buffer[position]; c < 'a' || c > 'z' {
+						goto l264
+					}
+					position++
+					goto l263
+				l264:
+					position, tokenIndex, depth = position263, tokenIndex263, depth263
+					if c := buffer[position]; c < 'A' || c > 'Z' {
+						goto l265
+					}
+					position++
+					goto l263
+				l265:
+					position, tokenIndex, depth = position263, tokenIndex263, depth263
+					if c := buffer[position]; c < '0' || c > '9' {
+						goto l266
+					}
+					position++
+					goto l263
+				l266:
+					position, tokenIndex, depth = position263, tokenIndex263, depth263
+					if buffer[position] != '_' {
+						goto l261
+					}
+					position++
+				}
+			l263:
+			l267:
+				{
+					position268, tokenIndex268, depth268 := position, tokenIndex, depth
+					{
+						position269, tokenIndex269, depth269 := position, tokenIndex, depth
+						if c := buffer[position]; c < 'a' || c > 'z' {
+							goto l270
+						}
+						position++
+						goto l269
+					l270:
+						position, tokenIndex, depth = position269, tokenIndex269, depth269
+						if c := buffer[position]; c < 'A' || c > 'Z' {
+							goto l271
+						}
+						position++
+						goto l269
+					l271:
+						position, tokenIndex, depth = position269, tokenIndex269, depth269
+						if c := buffer[position]; c < '0' || c > '9' {
+							goto l272
+						}
+						position++
+						goto l269
+					l272:
+						position, tokenIndex, depth = position269, tokenIndex269, depth269
+						if buffer[position] != '_' {
+							goto l273
+						}
+						position++
+						goto l269
+					l273:
+						position, tokenIndex, depth = position269, tokenIndex269, depth269
+						if buffer[position] != '-' {
+							goto l268
+						}
+						position++
+					}
+				l269:
+					goto l267
+				l268:
+					position, tokenIndex, depth = position268, tokenIndex268, depth268
 				}
-				position++
 				depth--
-				add(RuleComma, position66)
+				add(RuleMergeKey, position262)
 			}
 			return true
-		l65:
-			position, tokenIndex, depth = position65, tokenIndex65, depth65
+		l261:
+			position, tokenIndex, depth = position261, tokenIndex261, depth261
 			return false
 		},
-		/* 14 Integer <- <('-'? ([0-9] / '_')+)> */
+		/* 54 MergePath <- <(([a-z] / [A-Z] / [0-9] / '_') ([a-z] / [A-Z] / [0-9] / '_' / '-')* (('.' ([a-z] / [A-Z] / [0-9] / '_') ([a-z] / [A-Z] / [0-9] / '_' / '-')*) / ('.' '[' [0-9]+ ']'))*)> */
 		func() bool {
-			position67, tokenIndex67, depth67 := position, tokenIndex, depth
+			position274, tokenIndex274, depth274 := position, tokenIndex, depth
 			{
-				position68 := position
+				position275 := position
 				depth++
 				{
-					position69, tokenIndex69, depth69 := position, tokenIndex, depth
-					if buffer[position] != '-' {
-						goto l69
+					position276, tokenIndex276, depth276 := position, tokenIndex, depth
+					if c := buffer[position]; c < 'a' || c > 'z' {
+						goto l277
 					}
 					position++
-					goto l70
-				l69:
-					position, tokenIndex, depth = position69, tokenIndex69, depth69
-				}
-			l70:
-				{
-					position73, tokenIndex73, depth73 := position, tokenIndex, depth
+					goto l276
+				l277:
+					position, tokenIndex, depth = position276, tokenIndex276, depth276
+					if c := buffer[position]; c < 'A' || c > 'Z' {
+						goto l278
+					}
+					position++
+					goto l276
+				l278:
+					position, tokenIndex, depth = position276, tokenIndex276, depth276
 					if c := buffer[position]; c < '0' || c > '9' {
-						goto l74
+						goto l279
 					}
 					position++
-					goto l73
-				l74:
-					position, tokenIndex, depth = position73, tokenIndex73, depth73
+					goto l276
+				l279:
+					position, tokenIndex, depth = position276, tokenIndex276, depth276
 					if buffer[position] != '_' {
-						goto l67
+						goto l274
 					}
 					position++
 				}
-			l73:
-			l71:
+			l276:
+			l280:
 				{
-					position72, tokenIndex72, depth72 := position, tokenIndex, depth
+					position281, tokenIndex281, depth281 := position, tokenIndex, depth
 					{
-						position75, tokenIndex75, depth75 := position, tokenIndex, depth
+						position282, tokenIndex282, depth282 := position, tokenIndex, depth
+						if c := buffer[position]; c < 'a' || c > 'z' {
+							goto l283
+						}
+						position++
+						goto l282
+					l283:
+						position, tokenIndex, depth = position282, tokenIndex282, depth282
+						if c := buffer[position]; c < 'A' || c > 'Z' {
+							goto l284
+						}
+						position++
+						goto l282
+					l284:
+						position, tokenIndex, depth = position282, tokenIndex282, depth282
 						if c := buffer[position]; c < '0' || c > '9' {
-							goto l76
+							goto l285
 						}
 						position++
-						goto l75
-					l76:
-						position, tokenIndex, depth = position75, tokenIndex75, depth75
+						goto l282
+					l285:
+						position, tokenIndex, depth = position282, tokenIndex282, depth282
 						if buffer[position] != '_' {
-							goto l72
+							goto l286
+						}
+						position++
+						goto l282
+					l286:
+						position, tokenIndex, depth = position282, tokenIndex282, depth282
+						if buffer[position] != '-' {
+							goto l281
 						}
 						position++
 					}
-				l75:
-					goto l71
-				l72:
-					position, tokenIndex, depth = position72, tokenIndex72, depth72
-				}
-				depth--
-				add(RuleInteger, position68)
-			}
-			return true
-		l67:
-			position, tokenIndex, depth = position67, tokenIndex67, depth67
-			return false
-		},
-		/* 15 String <- <('"' (('\\' '"') / (!'"' .))* '"')> */
-		func() bool {
-			position77, tokenIndex77, depth77 := position, tokenIndex, depth
-			{
-				position78 := position
-				depth++
-				if buffer[position] != '"' {
-					goto l77
+				l282:
+					goto l280
+				l281:
+					position, tokenIndex, depth = position281, tokenIndex281, depth281
 				}
-				position++
-			l79:
+			l287:
 				{
-					position80, tokenIndex80, depth80 := position, tokenIndex, depth
+					position288, tokenIndex288, depth288 := position, tokenIndex, depth
 					{
-						position81, tokenIndex81, depth81 := position, tokenIndex, depth
-						if buffer[position] != '\\' {
-							goto l82
+						position289, tokenIndex289, depth289 := position, tokenIndex, depth
+						if buffer[position] != '.' {
+							goto l290
 						}
 						position++
-						if buffer[position] != '"' {
-							goto l82
+						{
+							position291, tokenIndex291, depth291 := position, tokenIndex, depth
+							if c := buffer[position]; c < 'a' || c > 'z' {
+								goto l292
+							}
+							position++
+							goto l291
+						l292:
+							position, tokenIndex, depth = position291, tokenIndex291, depth291
+							if c := buffer[position]; c < 'A' || c > 'Z' {
+								goto l293
+							}
+							position++
+							goto l291
+						l293:
+							position, tokenIndex, depth = position291, tokenIndex291, depth291
+							if c := buffer[position]; c < '0' || c > '9' {
+								goto l294
+							}
+							position++
+							goto l291
+						l294:
+							position, tokenIndex, depth = position291, tokenIndex291, depth291
+							if buffer[position] != '_' {
+								goto l290
+							}
+							position++
+						}
+					l291:
+					l295:
+						{
+							position296, tokenIndex296, depth296 := position, tokenIndex, depth
+							{
+								position297, tokenIndex297, depth297 := position, tokenIndex, depth
+								if c := buffer[position]; c < 'a' || c > 'z' {
+									goto l298
+								}
+								position++
+								goto l297
+							l298:
+								position, tokenIndex, depth = position297, tokenIndex297, depth297
+								if c := buffer[position]; c < 'A' || c > 'Z' {
+									goto l299
+								}
+								position++
+								goto l297
+							l299:
+								position, tokenIndex, depth = position297, tokenIndex297, depth297
+								if c := buffer[position]; c < '0' || c > '9' {
+									goto l300
+								}
+								position++
+								goto l297
+							l300:
+								position, tokenIndex, depth = position297, tokenIndex297, depth297
+								if buffer[position] != '_' {
+									goto l301
+								}
+								position++
+								goto l297
+							l301:
+								position, tokenIndex, depth = position297, tokenIndex297, depth297
+								if buffer[position] != '-' {
+									goto l296
+								}
+								position++
+							}
+						l297:
+							goto l295
+						l296:
+							position, tokenIndex, depth = position296, tokenIndex296, depth296
+						}
+						goto l289
+					l290:
+						position, tokenIndex, depth = position289, tokenIndex289, depth289
+						if buffer[position] != '.' {
+							goto l288
+						}
+						position++
+						if buffer[position] != '[' {
+							goto l288
 						}
 						position++
-						goto l81
-					l82:
-						position, tokenIndex, depth = position81, tokenIndex81, depth81
+						if c := buffer[position]; c < '0' || c > '9' {
+							goto l288
+						}
+						position++
+					l302:
 						{
-							position83, tokenIndex83, depth83 := position, tokenIndex, depth
-							if buffer[position] != '"' {
-								goto l83
+							position303, tokenIndex303, depth303 := position, tokenIndex, depth
+							if c := buffer[position]; c < '0' || c > '9' {
+								goto l303
 							}
 							position++
-							goto l80
-						l83:
-							position, tokenIndex, depth = position83, tokenIndex83, depth83
+							goto l302
+						l303:
+							position, tokenIndex, depth = position303, tokenIndex303, depth303
 						}
-						if !matchDot() {
-							goto l80
+						if buffer[position] != ']' {
+							goto l288
 						}
+						position++
 					}
-				l81:
-					goto l79
-				l80:
-					position, tokenIndex, depth = position80, tokenIndex80, depth80
-				}
-				if buffer[position] != '"' {
-					goto l77
-				}
-				position++
-				depth--
-				add(RuleString, position78)
-			}
-			return true
-		l77:
-			position, tokenIndex, depth = position77, tokenIndex77, depth77
-			return false
-		},
-		/* 16 Boolean <- <(('t' 'r' 'u' 'e') / ('f' 'a' 'l' 's' 'e'))> */
-		func() bool {
-			position84, tokenIndex84, depth84 := position, tokenIndex, depth
-			{
-				position85 := position
-				depth++
-				{
-					position86, tokenIndex86, depth86 := position, tokenIndex, depth
-					if buffer[position] != 't' {
-						goto l87
-					}
-					position++
-					if buffer[position] != 'r' {
-						goto l87
-					}
-					position++
-					if buffer[position] != 'u' {
-						goto l87
-					}
-					position++
-					if buffer[position] != 'e' {
-						goto l87
-					}
-					position++
-					goto l86
-				l87:
-					position, tokenIndex, depth = position86, tokenIndex86, depth86
-					if buffer[position] != 'f' {
-						goto l84
-					}
-					position++
-					if buffer[position] != 'a' {
-						goto l84
-					}
-					position++
-					if buffer[position] != 'l' {
-						goto l84
-					}
-					position++
-					if buffer[position] != 's' {
-						goto l84
-					}
-					position++
-					if buffer[position] != 'e' {
-						goto l84
-					}
-					position++
+				l289:
+					goto l287
+				l288:
+					position, tokenIndex, depth = position288, tokenIndex288, depth288
 				}
-			l86:
 				depth--
-				add(RuleBoolean, position85)
+				add(RuleMergePath, position275)
 			}
 			return true
-		l84:
-			position, tokenIndex, depth = position84, tokenIndex84, depth84
+		l274:
+			position, tokenIndex, depth = position274, tokenIndex274, depth274
 			return false
 		},
-		/* 17 Nil <- <('n' 'i' 'l')> */
+		/* 55 Auto <- <('a' 'u' 't' 'o')> */
 		func() bool {
-			position88, tokenIndex88, depth88 := position, tokenIndex, depth
+			position304, tokenIndex304, depth304 := position, tokenIndex, depth
 			{
-				position89 := position
+				position305 := position
 				depth++
-				if buffer[position] != 'n' {
-					goto l88
-				}
-				position++
-				if buffer[position] != 'i' {
-					goto l88
+				if buffer[position] != 'a' {
+					goto l304
 				}
 				position++
-				if buffer[position] != 'l' {
-					goto l88
+				if buffer[position] != 'u' {
+					goto l304
 				}
 				position++
-				depth--
-				add(RuleNil, position89)
-			}
-			return true
-		l88:
-			position, tokenIndex, depth = position88, tokenIndex88, depth88
-			return false
-		},
-		/* 18 List <- <('[' Contents? ']')> */
-		func() bool {
-			position90, tokenIndex90, depth90 := position, tokenIndex, depth
-			{
-				position91 := position
-				depth++
-				if buffer[position] != '[' {
-					goto l90
+				if buffer[position] != 't' {
+					goto l304
 				}
 				position++
-				{
-					position92, tokenIndex92, depth92 := position, tokenIndex, depth
-					if !rules[RuleContents]() {
-						goto l92
-					}
-					goto l93
-				l92:
-					position, tokenIndex, depth = position92, tokenIndex92, depth92
-				}
-			l93:
-				if buffer[position] != ']' {
-					goto l90
+				if buffer[position] != 'o' {
+					goto l304
 				}
 				position++
 				depth--
-				add(RuleList, position91)
-			}
-			return true
-		l90:
-			position, tokenIndex, depth = position90, tokenIndex90, depth90
-			return false
-		},
-		/* 19 Contents <- <(Expression (Comma ws Expression)*)> */
-		func() bool {
-			position94, tokenIndex94, depth94 := position, tokenIndex, depth
-			{
-				position95 := position
-				depth++
-				if !rules[RuleExpression]() {
-					goto l94
-				}
-			l96:
-				{
-					position97, tokenIndex97, depth97 := position, tokenIndex, depth
-					if !rules[RuleComma]() {
-						goto l97
-					}
-					if !rules[Rulews]() {
-						goto l97
-					}
-					if !rules[RuleExpression]() {
-						goto l97
-					}
-					goto l96
-				l97:
-					position, tokenIndex, depth = position97, tokenIndex97, depth97
-				}
-				depth--
-				add(RuleContents, position95)
+				add(RuleAuto, position305)
 			}
 			return true
-		l94:
-			position, tokenIndex, depth = position94, tokenIndex94, depth94
+		l304:
+			position, tokenIndex, depth = position304, tokenIndex304, depth304
 			return false
 		},
-		/* 20 Merge <- <('m' 'e' 'r' 'g' 'e')> */
+		/* 56 Delete <- <(('d' 'e' 'l' 'e' 't' 'e') !([a-z] / [A-Z] / [0-9] / '_' / '-' / '.'))> */
 		func() bool {
-			position98, tokenIndex98, depth98 := position, tokenIndex, depth
+			position306, tokenIndex306, depth306 := position, tokenIndex, depth
 			{
-				position99 := position
+				position307 := position
 				depth++
-				if buffer[position] != 'm' {
-					goto l98
+				if buffer[position] != 'd' {
+					goto l306
 				}
 				position++
 				if buffer[position] != 'e' {
-					goto l98
-				}
-				position++
-				if buffer[position] != 'r' {
-					goto l98
+					goto l306
 				}
 				position++
-				if buffer[position] != 'g' {
-					goto l98
+				if buffer[position] != 'l' {
+					goto l306
 				}
 				position++
 				if buffer[position] != 'e' {
-					goto l98
-				}
-				position++
-				depth--
-				add(RuleMerge, position99)
-			}
-			return true
-		l98:
-			position, tokenIndex, depth = position98, tokenIndex98, depth98
-			return false
-		},
-		/* 21 Auto <- <('a' 'u' 't' 'o')> */
-		func() bool {
-			position100, tokenIndex100, depth100 := position, tokenIndex, depth
-			{
-				position101 := position
-				depth++
-				if buffer[position] != 'a' {
-					goto l100
-				}
-				position++
-				if buffer[position] != 'u' {
-					goto l100
+					goto l306
 				}
 				position++
 				if buffer[position] != 't' {
-					goto l100
+					goto l306
 				}
 				position++
-				if buffer[position] != 'o' {
-					goto l100
+				if buffer[position] != 'e' {
+					goto l306
 				}
 				position++
+				{
+					position308, tokenIndex308, depth308 := position, tokenIndex, depth
+					{
+						position309, tokenIndex309, depth309 := position, tokenIndex, depth
+						if c := buffer[position]; c < 'a' || c > 'z' {
+							goto l310
+						}
+						position++
+						goto l309
+					l310:
+						position, tokenIndex, depth = position309, tokenIndex309, depth309
+						if c := buffer[position]; c < 'A' || c > 'Z' {
+							goto l311
+						}
+						position++
+						goto l309
+					l311:
+						position, tokenIndex, depth = position309, tokenIndex309, depth309
+						if c := buffer[position]; c < '0' || c > '9' {
+							goto l312
+						}
+						position++
+						goto l309
+					l312:
+						position, tokenIndex, depth = position309, tokenIndex309, depth309
+						if buffer[position] != '_' {
+							goto l313
+						}
+						position++
+						goto l309
+					l313:
+						position, tokenIndex, depth = position309, tokenIndex309, depth309
+						if buffer[position] != '-' {
+							goto l314
+						}
+						position++
+						goto l309
+					l314:
+						position, tokenIndex, depth = position309, tokenIndex309, depth309
+						if buffer[position] != '.' {
+							goto l308
+						}
+						position++
+					}
+				l309:
+					goto l306
+				l308:
+					position, tokenIndex, depth = position308, tokenIndex308, depth308
+				}
 				depth--
-				add(RuleAuto, position101)
+				add(RuleDelete, position307)
 			}
 			return true
-		l100:
-			position, tokenIndex, depth = position100, tokenIndex100, depth100
+		l306:
+			position, tokenIndex, depth = position306, tokenIndex306, depth306
 			return false
 		},
-		/* 22 Reference <- <('.'? ([a-z] / [A-Z] / [0-9] / '_') ([a-z] / [A-Z] / [0-9] / '_' / '-')* (('.' ([a-z] / [A-Z] / [0-9] / '_') ([a-z] / [A-Z] / [0-9] / '_' / '-')*) / ('.' '[' [0-9]+ ']'))*)> */
+		/* 57 Reference <- <('.'? ([a-z] / [A-Z] / [0-9] / '_') ([a-z] / [A-Z] / [0-9] / '_' / '-')* (('.' ([a-z] / [A-Z] / [0-9] / '_') ([a-z] / [A-Z] / [0-9] / '_' / '-')*) / ('.' '[' [0-9]+ ']'))*)> */
 		func() bool {
-			position102, tokenIndex102, depth102 := position, tokenIndex, depth
+			position315, tokenIndex315, depth315 := position, tokenIndex, depth
 			{
-				position103 := position
+				position316 := position
 				depth++
 				{
-					position104, tokenIndex104, depth104 := position, tokenIndex, depth
+					position317, tokenIndex317, depth317 := position, tokenIndex, depth
 					if buffer[position] != '.' {
-						goto l104
+						goto l317
 					}
 					position++
-					goto l105
-				l104:
-					position, tokenIndex, depth = position104, tokenIndex104, depth104
+					goto l318
+				l317:
+					position, tokenIndex, depth = position317, tokenIndex317, depth317
 				}
-			l105:
+			l318:
 				{
-					position106, tokenIndex106, depth106 := position, tokenIndex, depth
+					position319, tokenIndex319, depth319 := position, tokenIndex, depth
 					if c := buffer[position]; c < 'a' || c > 'z' {
-						goto l107
+						goto l320
 					}
 					position++
-					goto l106
-				l107:
-					position, tokenIndex, depth = position106, tokenIndex106, depth106
+					goto l319
+				l320:
+					position, tokenIndex, depth = position319, tokenIndex319, depth319
 					if c := buffer[position]; c < 'A' || c > 'Z' {
-						goto l108
+						goto l321
 					}
 					position++
-					goto l106
-				l108:
-					position, tokenIndex, depth = position106, tokenIndex106, depth106
+					goto l319
+				l321:
+					position, tokenIndex, depth = position319, tokenIndex319, depth319
 					if c := buffer[position]; c < '0' || c > '9' {
-						goto l109
+						goto l322
 					}
 					position++
-					goto l106
-				l109:
-					position, tokenIndex, depth = position106, tokenIndex106, depth106
+					goto l319
+				l322:
+					position, tokenIndex, depth = position319, tokenIndex319, depth319
 					if buffer[position] != '_' {
-						goto l102
+						goto l315
 					}
 					position++
 				}
-			l106:
-			l110:
+			l319:
+			l323:
 				{
-					position111, tokenIndex111, depth111 := position, tokenIndex, depth
+					position324, tokenIndex324, depth324 := position, tokenIndex, depth
 					{
-						position112, tokenIndex112, depth112 := position, tokenIndex, depth
+						position325, tokenIndex325, depth325 := position, tokenIndex, depth
 						if c := buffer[position]; c < 'a' || c > 'z' {
-							goto l113
+							goto l326
 						}
 						position++
-						goto l112
-					l113:
-						position, tokenIndex, depth = position112, tokenIndex112, depth112
+						goto l325
+					l326:
+						position, tokenIndex, depth = position325, tokenIndex325, depth325
 						if c := buffer[position]; c < 'A' || c > 'Z' {
-							goto l114
+							goto l327
 						}
 						position++
-						goto l112
-					l114:
-						position, tokenIndex, depth = position112, tokenIndex112, depth112
+						goto l325
+					l327:
+						position, tokenIndex, depth = position325, tokenIndex325, depth325
 						if c := buffer[position]; c < '0' || c > '9' {
-							goto l115
+							goto l328
 						}
 						position++
-						goto l112
-					l115:
-						position, tokenIndex, depth = position112, tokenIndex112, depth112
+						goto l325
+					l328:
+						position, tokenIndex, depth = position325, tokenIndex325, depth325
 						if buffer[position] != '_' {
-							goto l116
+							goto l329
 						}
 						position++
-						goto l112
-					l116:
-						position, tokenIndex, depth = position112, tokenIndex112, depth112
+						goto l325
+					l329:
+						position, tokenIndex, depth = position325, tokenIndex325, depth325
 						if buffer[position] != '-' {
-							goto l111
+							goto l324
 						}
 						position++
 					}
-				l112:
-					goto l110
-				l111:
-					position, tokenIndex, depth = position111, tokenIndex111, depth111
+				l325:
+					goto l323
+				l324:
+					position, tokenIndex, depth = position324, tokenIndex324, depth324
 				}
-			l117:
+			l330:
 				{
-					position118, tokenIndex118, depth118 := position, tokenIndex, depth
+					position331, tokenIndex331, depth331 := position, tokenIndex, depth
 					{
-						position119, tokenIndex119, depth119 := position, tokenIndex, depth
+						position332, tokenIndex332, depth332 := position, tokenIndex, depth
 						if buffer[position] != '.' {
-							goto l120
+							goto l333
 						}
 						position++
 						{
-							position121, tokenIndex121, depth121 := position, tokenIndex, depth
+							position334, tokenIndex334, depth334 := position, tokenIndex, depth
 							if c := buffer[position]; c < 'a' || c > 'z' {
-								goto l122
+								goto l335
 							}
 							position++
-							goto l121
-						l122:
-							position, tokenIndex, depth = position121, tokenIndex121, depth121
+							goto l334
+						l335:
+							position, tokenIndex, depth = position334, tokenIndex334, depth334
 							if c := buffer[position]; c < 'A' || c > 'Z' {
-								goto l123
+								goto l336
 							}
 							position++
-							goto l121
-						l123:
-							position, tokenIndex, depth = position121, tokenIndex121, depth121
+							goto l334
+						l336:
+							position, tokenIndex, depth = position334, tokenIndex334, depth334
 							if c := buffer[position]; c < '0' || c > '9' {
-								goto l124
+								goto l337
 							}
 							position++
-							goto l121
-						l124:
-							position, tokenIndex, depth = position121, tokenIndex121, depth121
+							goto l334
+						l337:
+							position, tokenIndex, depth = position334, tokenIndex334, depth334
 							if buffer[position] != '_' {
-								goto l120
+								goto l333
 							}
 							position++
 						}
-					l121:
-					l125:
+					l334:
+					l338:
 						{
-							position126, tokenIndex126, depth126 := position, tokenIndex, depth
+							position339, tokenIndex339, depth339 := position, tokenIndex, depth
 							{
-								position127, tokenIndex127, depth127 := position, tokenIndex, depth
+								position340, tokenIndex340, depth340 := position, tokenIndex, depth
 								if c := buffer[position]; c < 'a' || c > 'z' {
-									goto l128
+									goto l341
 								}
 								position++
-								goto l127
-							l128:
-								position, tokenIndex, depth = position127, tokenIndex127, depth127
+								goto l340
+							l341:
+								position, tokenIndex, depth = position340, tokenIndex340, depth340
 								if c := buffer[position]; c < 'A' || c > 'Z' {
-									goto l129
+									goto l342
 								}
 								position++
-								goto l127
-							l129:
-								position, tokenIndex, depth = position127, tokenIndex127, depth127
+								goto l340
+							l342:
+								position, tokenIndex, depth = position340, tokenIndex340, depth340
 								if c := buffer[position]; c < '0' || c > '9' {
-									goto l130
+									goto l343
 								}
 								position++
-								goto l127
-							l130:
-								position, tokenIndex, depth = position127, tokenIndex127, depth127
+								goto l340
+							l343:
+								position, tokenIndex, depth = position340, tokenIndex340, depth340
 								if buffer[position] != '_' {
-									goto l131
+									goto l344
 								}
 								position++
-								goto l127
-							l131:
-								position, tokenIndex, depth = position127, tokenIndex127, depth127
+								goto l340
+							l344:
+								position, tokenIndex, depth = position340, tokenIndex340, depth340
 								if buffer[position] != '-' {
-									goto l126
+									goto l339
 								}
 								position++
 							}
-						l127:
-							goto l125
-						l126:
-							position, tokenIndex, depth = position126, tokenIndex126, depth126
+						l340:
+							goto l338
+						l339:
+							position, tokenIndex, depth = position339, tokenIndex339, depth339
 						}
-						goto l119
-					l120:
-						position, tokenIndex, depth = position119, tokenIndex119, depth119
+						goto l332
+					l333:
+						position, tokenIndex, depth = position332, tokenIndex332, depth332
 						if buffer[position] != '.' {
-							goto l118
+							goto l331
 						}
 						position++
 						if buffer[position] != '[' {
-							goto l118
+							goto l331
 						}
 						position++
 						if c := buffer[position]; c < '0' || c > '9' {
-							goto l118
+							goto l331
 						}
 						position++
-					l132:
+					l345:
 						{
-							position133, tokenIndex133, depth133 := position, tokenIndex, depth
+							position346, tokenIndex346, depth346 := position, tokenIndex, depth
 							if c := buffer[position]; c < '0' || c > '9' {
-								goto l133
+								goto l346
 							}
 							position++
-							goto l132
-						l133:
-							position, tokenIndex, depth = position133, tokenIndex133, depth133
+							goto l345
+						l346:
+							position, tokenIndex, depth = position346, tokenIndex346, depth346
 						}
 						if buffer[position] != ']' {
-							goto l118
+							goto l331
 						}
 						position++
 					}
-				l119:
-					goto l117
-				l118:
-					position, tokenIndex, depth = position118, tokenIndex118, depth118
+				l332:
+					goto l330
+				l331:
+					position, tokenIndex, depth = position331, tokenIndex331, depth331
 				}
 				depth--
-				add(RuleReference, position103)
+				add(RuleReference, position316)
 			}
 			return true
-		l102:
-			position, tokenIndex, depth = position102, tokenIndex102, depth102
+		l315:
+			position, tokenIndex, depth = position315, tokenIndex315, depth315
 			return false
 		},
-		/* 23 ws <- <(' ' / '\t' / '\n' / '\r')*> */
+		/* 58 ws <- <(' ' / '\t' / '\n' / '\r')*> */
 		func() bool {
 			{
-				position135 := position
+				position348 := position
 				depth++
-			l136:
+			l349:
 				{
-					position137, tokenIndex137, depth137 := position, tokenIndex, depth
+					position350, tokenIndex350, depth350 := position, tokenIndex, depth
 					{
-						position138, tokenIndex138, depth138 := position, tokenIndex, depth
+						position351, tokenIndex351, depth351 := position, tokenIndex, depth
 						if buffer[position] != ' ' {
-							goto l139
+							goto l352
 						}
 						position++
-						goto l138
-					l139:
-						position, tokenIndex, depth = position138, tokenIndex138, depth138
+						goto l351
+					l352:
+						position, tokenIndex, depth = position351, tokenIndex351, depth351
 						if buffer[position] != '\t' {
-							goto l140
+							goto l353
 						}
 						position++
-						goto l138
-					l140:
-						position, tokenIndex, depth = position138, tokenIndex138, depth138
+						goto l351
+					l353:
+						position, tokenIndex, depth = position351, tokenIndex351, depth351
 						if buffer[position] != '\n' {
-							goto l141
+							goto l354
 						}
 						position++
-						goto l138
-					l141:
-						position, tokenIndex, depth = position138, tokenIndex138, depth138
+						goto l351
+					l354:
+						position, tokenIndex, depth = position351, tokenIndex351, depth351
 						if buffer[position] != '\r' {
-							goto l137
+							goto l350
 						}
 						position++
 					}
-				l138:
-					goto l136
-				l137:
-					position, tokenIndex, depth = position137, tokenIndex137, depth137
+				l351:
+					goto l349
+				l350:
+					position, tokenIndex, depth = position350, tokenIndex350, depth350
 				}
 				depth--
-				add(Rulews, position135)
+				add(Rulews, position348)
 			}
 			return true
 		},
-		/* 24 req_ws <- <(' ' / '\t' / '\n' / '\r')+> */
+		/* 59 req_ws <- <(' ' / '\t' / '\n' / '\r')+> */
 		func() bool {
-			position142, tokenIndex142, depth142 := position, tokenIndex, depth
+			position355, tokenIndex355, depth355 := position, tokenIndex, depth
 			{
-				position143 := position
+				position356 := position
 				depth++
 				{
-					position146, tokenIndex146, depth146 := position, tokenIndex, depth
+					position359, tokenIndex359, depth359 := position, tokenIndex, depth
 					if buffer[position] != ' ' {
-						goto l147
+						goto l360
 					}
 					position++
-					goto l146
-				l147:
-					position, tokenIndex, depth = position146, tokenIndex146, depth146
+					goto l359
+				l360:
+					position, tokenIndex, depth = position359, tokenIndex359, depth359
 					if buffer[position] != '\t' {
-						goto l148
+						goto l361
 					}
 					position++
-					goto l146
-				l148:
-					position, tokenIndex, depth = position146, tokenIndex146, depth146
+					goto l359
+				l361:
+					position, tokenIndex, depth = position359, tokenIndex359, depth359
 					if buffer[position] != '\n' {
-						goto l149
+						goto l362
 					}
 					position++
-					goto l146
-				l149:
-					position, tokenIndex, depth = position146, tokenIndex146, depth146
+					goto l359
+				l362:
+					position, tokenIndex, depth = position359, tokenIndex359, depth359
 					if buffer[position] != '\r' {
-						goto l142
+						goto l355
 					}
 					position++
 				}
-			l146:
-			l144:
+			l359:
+			l357:
 				{
-					position145, tokenIndex145, depth145 := position, tokenIndex, depth
+					position358, tokenIndex358, depth358 := position, tokenIndex, depth
 					{
-						position150, tokenIndex150, depth150 := position, tokenIndex, depth
+						position363, tokenIndex363, depth363 := position, tokenIndex, depth
 						if buffer[position] != ' ' {
-							goto l151
+							goto l364
 						}
 						position++
-						goto l150
-					l151:
-						position, tokenIndex, depth = position150, tokenIndex150, depth150
+						goto l363
+					l364:
+						position, tokenIndex, depth = position363, tokenIndex363, depth363
 						if buffer[position] != '\t' {
-							goto l152
+							goto l365
 						}
 						position++
-						goto l150
-					l152:
-						position, tokenIndex, depth = position150, tokenIndex150, depth150
+						goto l363
+					l365:
+						position, tokenIndex, depth = position363, tokenIndex363, depth363
 						if buffer[position] != '\n' {
-							goto l153
+							goto l366
 						}
 						position++
-						goto l150
-					l153:
-						position, tokenIndex, depth = position150, tokenIndex150, depth150
+						goto l363
+					l366:
+						position, tokenIndex, depth = position363, tokenIndex363, depth363
 						if buffer[position] != '\r' {
-							goto l145
+							goto l358
 						}
 						position++
 					}
-				l150:
-					goto l144
-				l145:
-					position, tokenIndex, depth = position145, tokenIndex145, depth145
+				l363:
+					goto l357
+				l358:
+					position, tokenIndex, depth = position358, tokenIndex358, depth358
 				}
 				depth--
-				add(Rulereq_ws, position143)
+				add(Rulereq_ws, position356)
 			}
 			return true
-		l142:
-			position, tokenIndex, depth = position142, tokenIndex142, depth142
+		l355:
+			position, tokenIndex, depth = position355, tokenIndex355, depth355
 			return false
 		},
 	}
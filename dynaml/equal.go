@@ -0,0 +1,64 @@
+package dynaml
+
+import (
+	"fmt"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+type EqualExpr struct {
+	A Expression
+	B Expression
+}
+
+func (e EqualExpr) Evaluate(binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	return evaluateEquality(e, e.A, e.B, false, binding)
+}
+
+func (e EqualExpr) String() string {
+	return fmt.Sprintf("%s == %s", e.A, e.B)
+}
+
+type NotEqualExpr struct {
+	A Expression
+	B Expression
+}
+
+func (e NotEqualExpr) Evaluate(binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	return evaluateEquality(e, e.A, e.B, true, binding)
+}
+
+func (e NotEqualExpr) String() string {
+	return fmt.Sprintf("%s != %s", e.A, e.B)
+}
+
+// evaluateEquality compares both sides structurally, so that maps and
+// lists are equal if their contents are. negate gives the result of !=.
+func evaluateEquality(operator Expression, ea, eb Expression, negate bool, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	a, info, ok := ea.Evaluate(binding)
+	if !ok {
+		return nil, info, false
+	}
+
+	if isExpression(a) {
+		return unresolvedOperand(operator, info, binding)
+	}
+
+	b, info, ok := eb.Evaluate(binding)
+	if !ok {
+		return nil, info, false
+	}
+
+	if isExpression(b) {
+		return unresolvedOperand(operator, info, binding)
+	}
+
+	// numbers are equal by value, regardless of being ints or floats
+	_, _, bothInts := asInts(a, b)
+	afloat, bfloat, bothNumbers := asFloats(a, b)
+	if bothNumbers && !bothInts {
+		return node((afloat == bfloat) != negate, binding), info, true
+	}
+
+	return node(a.EquivalentToNode(b) != negate, binding), info, true
+}
@@ -0,0 +1,79 @@
+package dynaml
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+var _ = Describe("equality", func() {
+	It("compares scalars", func() {
+		Expect(EqualExpr{IntegerExpr{1}, IntegerExpr{1}}).To(EvaluateAs(true, FakeBinding{}))
+		Expect(EqualExpr{IntegerExpr{1}, IntegerExpr{2}}).To(EvaluateAs(false, FakeBinding{}))
+		Expect(EqualExpr{StringExpr{"1"}, IntegerExpr{1}}).To(EvaluateAs(false, FakeBinding{}))
+		Expect(EqualExpr{NilExpr{}, NilExpr{}}).To(EvaluateAs(true, FakeBinding{}))
+	})
+
+	It("compares ints and floats by value", func() {
+		Expect(EqualExpr{IntegerExpr{2}, FloatExpr{2.0}}).To(EvaluateAs(true, FakeBinding{}))
+		Expect(EqualExpr{FloatExpr{2.5}, IntegerExpr{2}}).To(EvaluateAs(false, FakeBinding{}))
+	})
+
+	It("compares lists and maps structurally", func() {
+		binding := FakeBinding{
+			FoundReferences: map[string]yaml.Node{
+				"a": node(map[string]yaml.Node{"x": node("y", nil)}, nil),
+				"b": node(map[string]yaml.Node{"x": node("y", nil)}, nil),
+				"c": node(map[string]yaml.Node{"x": node("z", nil)}, nil),
+			},
+		}
+
+		Expect(EqualExpr{
+			ListExpr{[]Expression{IntegerExpr{1}, StringExpr{"two"}}},
+			ListExpr{[]Expression{IntegerExpr{1}, StringExpr{"two"}}},
+		}).To(EvaluateAs(true, binding))
+
+		Expect(EqualExpr{
+			ReferenceExpr{[]string{"a"}},
+			ReferenceExpr{[]string{"b"}},
+		}).To(EvaluateAs(true, binding))
+
+		Expect(EqualExpr{
+			ReferenceExpr{[]string{"a"}},
+			ReferenceExpr{[]string{"c"}},
+		}).To(EvaluateAs(false, binding))
+	})
+
+	It("negates the result for !=", func() {
+		Expect(NotEqualExpr{IntegerExpr{1}, IntegerExpr{1}}).To(EvaluateAs(false, FakeBinding{}))
+		Expect(NotEqualExpr{IntegerExpr{1}, IntegerExpr{2}}).To(EvaluateAs(true, FakeBinding{}))
+	})
+
+	Context("when a side is not resolved yet", func() {
+		It("stays unresolved, passing on why", func() {
+			expr := EqualExpr{
+				ReferenceExpr{[]string{"foo"}},
+				IntegerExpr{1},
+			}
+
+			binding := FakeBinding{
+				FoundReferences: map[string]yaml.Node{
+					"foo": node(MergeExpr{}, nil),
+				},
+			}
+
+			result, info, ok := expr.Evaluate(binding)
+			Expect(ok).To(BeTrue())
+			Expect(result.Value()).To(Equal(expr))
+			Expect(info.Issue).To(Equal("foo is not resolved yet"))
+		})
+	})
+
+	Context("when a side fails", func() {
+		It("fails", func() {
+			Expect(EqualExpr{FailingExpr{}, IntegerExpr{1}}).To(FailToEvaluate(FakeBinding{}))
+			Expect(NotEqualExpr{IntegerExpr{1}, FailingExpr{}}).To(FailToEvaluate(FakeBinding{}))
+		})
+	})
+})
@@ -7,7 +7,7 @@ import (
 )
 
 func EvaluateAs(expected interface{}, binding Binding) *EvaluateAsMatcher {
-	return &EvaluateAsMatcher{Expected: node(expected), Binding: binding}
+	return &EvaluateAsMatcher{Expected: node(expected, nil), Binding: binding}
 }
 
 type EvaluateAsMatcher struct {
@@ -26,18 +26,12 @@ func (matcher *EvaluateAsMatcher) Match(source interface{}) (success bool, err e
 		return false, fmt.Errorf("Not an expression: %v\n", source)
 	}
 
-	matcher.actual, ok = expr.Evaluate(matcher.Binding)
+	matcher.actual, _, ok = expr.Evaluate(matcher.Binding)
 	if matcher.actual == nil || !ok {
 		return false, fmt.Errorf("Node failed to evaluate.")
 	}
 
-	if matcher.actual.EquivalentToNode(matcher.Expected) {
-		return true, nil
-	} else {
-		return false, nil
-	}
-
-	return
+	return matcher.actual.EquivalentToNode(matcher.Expected), nil
 }
 
 func formatMessage(actual interface{}, message string, expected interface{}) string {
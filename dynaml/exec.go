@@ -0,0 +1,76 @@
+package dynaml
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+// ExecCache remembers the results of the commands run by exec(), so that each
+// command runs once per merge, however often its expression is evaluated.
+// Commands can only be run with bindings that provide a cache.
+type ExecCache struct {
+	lock    sync.Mutex
+	results map[string]execResult
+}
+
+type execResult struct {
+	node yaml.Node
+	err  error
+}
+
+func NewExecCache() *ExecCache {
+	return &ExecCache{results: map[string]execResult{}}
+}
+
+// Run runs the command and parses its output as YAML data, unless the same command
+// with the same arguments has been run before.
+func (c *ExecCache) Run(command []string) (yaml.Node, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := strings.Join(command, "\x00")
+
+	result, found := c.results[key]
+	if !found {
+		result.node, result.err = runCommand(command)
+		c.results[key] = result
+	}
+
+	return result.node, result.err
+}
+
+func runCommand(command []string) (yaml.Node, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			return nil, fmt.Errorf("%s: %s", command[0], err)
+		}
+
+		return nil, fmt.Errorf("%s: %s: %s", command[0], err, message)
+	}
+
+	if strings.TrimSpace(stdout.String()) == "" {
+		return yaml.NewNode(nil, strings.Join(command, " ")), nil
+	}
+
+	// the output is data, not part of the templates, so dynaml in it is
+	// left as it is
+	node, err := yaml.Parse(strings.Join(command, " "), stdout.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.LiteralNode(node), nil
+}
@@ -1,15 +1,85 @@
 package dynaml
 
 import (
+	"fmt"
+
 	"github.com/cloudfoundry-incubator/spiff/yaml"
 )
 
 type Binding interface {
+	Origin() yaml.Origin
+
 	FindFromRoot([]string) (yaml.Node, bool)
 	FindReference([]string) (yaml.Node, bool)
 	FindInStubs([]string) (yaml.Node, bool)
+
+	// ExecCache is nil unless commands may be run with exec().
+	ExecCache() *ExecCache
+}
+
+// EvaluationInfo carries additional information about the evaluation of an
+// expression, most importantly the reason why it could not be resolved.
+type EvaluationInfo struct {
+	Issue string
 }
 
 type Expression interface {
-	Evaluate(Binding) (yaml.Node, bool)
+	Evaluate(Binding) (yaml.Node, EvaluationInfo, bool)
+}
+
+func DefaultInfo() EvaluationInfo {
+	return EvaluationInfo{}
+}
+
+// Error returns a failed evaluation result whose issue is the formatted
+// message.
+func (i EvaluationInfo) Error(format string, args ...interface{}) (yaml.Node, EvaluationInfo, bool) {
+	i.Issue = fmt.Sprintf(format, args...)
+	return nil, i, false
+}
+
+// unresolvedOperand leaves an operator unresolved for as long as one of its
+// operands is, passing on why the operand is not resolved.
+func unresolvedOperand(operator Expression, info EvaluationInfo, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	if info.Issue == "" {
+		info.Issue = fmt.Sprintf("an operand of %s is not resolved yet", operator)
+	}
+
+	return node(operator, binding), info, true
+}
+
+// typeName describes the type of a node's value for use in issues.
+func typeName(n yaml.Node) string {
+	if n == nil {
+		return "nil"
+	}
+
+	switch n.Value().(type) {
+	case nil:
+		return "nil"
+	case string:
+		return "string"
+	case int64:
+		return "int"
+	case float64:
+		return "float"
+	case bool:
+		return "bool"
+	case []yaml.Node:
+		return "list"
+	case map[string]yaml.Node:
+		return "map"
+	case LambdaExpr:
+		return "lambda"
+	case Expression:
+		return "unresolved expression"
+	}
+
+	return fmt.Sprintf("%T", n.Value())
+}
+
+// isExpression tells whether a node still holds an unresolved expression.
+func isExpression(n yaml.Node) bool {
+	_, ok := n.Value().(Expression)
+	return ok
 }
@@ -0,0 +1,211 @@
+package dynaml
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+func init() {
+	RegisterFunction(Function{
+		Name:           "env",
+		Arguments:      []ArgumentType{StringArgument},
+		Implementation: funcEnv,
+	})
+
+	RegisterFunction(Function{
+		Name:           "read",
+		Arguments:      []ArgumentType{StringArgument, StringArgument},
+		Optional:       1,
+		Implementation: funcRead,
+	})
+
+	RegisterFunction(Function{
+		Name:           "exec",
+		Arguments:      []ArgumentType{StringArgument, AnyArgument},
+		Optional:       1,
+		Variadic:       true,
+		Implementation: funcExec,
+	})
+}
+
+// env(name) is the value of an environment variable. It fails if the
+// variable is not set, so that a default can be given with ||.
+func funcEnv(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	name := args[0].Value().(string)
+
+	val, found := os.LookupEnv(name)
+	if !found {
+		return DefaultInfo().Error("environment variable %s is not set", name)
+	}
+
+	return node(val, binding), DefaultInfo(), true
+}
+
+// read(path) reads a file as YAML, JSON or text depending on its extension;
+// read(path, type) gives the type explicitly. Relative paths are relative to
+// the document read() is used in.
+//
+// YAML documents keep their own origins, so that their expressions are
+// flowed in place with the right source names, and reads within them are
+// relative to where they are.
+func funcRead(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	info := DefaultInfo()
+	origin := binding.Origin()
+
+	file := args[0].Value().(string)
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(filepath.Dir(origin.SourceName), file)
+	}
+
+	fileType := readType(file)
+	if len(args) > 1 {
+		fileType = args[1].Value().(string)
+	}
+
+	if fileType != "yaml" && fileType != "json" && fileType != "text" {
+		return info.Error("read: type must be yaml, json or text, but is '%s'", fileType)
+	}
+
+	cycle, found := includeCycle(origin, file)
+	if found {
+		return info.Error("read: include cycle %s", strings.Join(cycle, " -> "))
+	}
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return info.Error("read: %s", err)
+	}
+
+	switch fileType {
+	case "yaml":
+		doc, err := yaml.ParseIncluded(file, data, origin)
+		if err != nil {
+			return info.Error("read: error parsing %s: %s", file, err)
+		}
+
+		return doc, info, true
+
+	case "json":
+		var parsed interface{}
+
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+
+		err := decoder.Decode(&parsed)
+		if err != nil {
+			return info.Error("read: error parsing %s: %s", file, err)
+		}
+
+		// unlike YAML files, which are templates themselves, JSON and text
+		// are data, so dynaml in them is left as it is
+		return yaml.LiteralNode(jsonNode(parsed, yaml.Origin{SourceName: file, IncludedFrom: &origin})), info, true
+	}
+
+	return yaml.LiteralNode(node(string(data), binding)), info, true
+}
+
+// exec(command, args...) runs a command and parses its output as YAML. It is
+// only available if spiff is explicitly told to run commands, and runs each
+// command only once per merge.
+func funcExec(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	cache := binding.ExecCache()
+	if cache == nil {
+		return info.Error("exec: running commands is disabled, use spiff merge --enable-exec")
+	}
+
+	command := make([]string, len(args))
+	for i, arg := range args {
+		switch val := arg.Value().(type) {
+		case string:
+			command[i] = val
+		case int64, float64, bool:
+			command[i] = fmt.Sprintf("%v", val)
+		default:
+			return info.Error("exec: argument %d must be a string, number or bool, but is %s", i+1, typeName(arg))
+		}
+	}
+
+	result, err := cache.Run(command)
+	if err != nil {
+		return info.Error("exec: %s", err)
+	}
+
+	return result, info, true
+}
+
+func readType(file string) string {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yml", ".yaml":
+		return "yaml"
+	case ".json":
+		return "json"
+	}
+
+	return "text"
+}
+
+// includeCycle returns the chain of reads leading from the file back to
+// itself, if the document at the origin was read from it.
+func includeCycle(origin yaml.Origin, file string) ([]string, bool) {
+	chain := []string{file}
+
+	for including := &origin; including != nil; including = including.IncludedFrom {
+		chain = append([]string{including.SourceName}, chain...)
+
+		if samePath(including.SourceName, file) {
+			return chain, true
+		}
+	}
+
+	return nil, false
+}
+
+func samePath(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	if errA != nil || errB != nil {
+		return filepath.Clean(a) == filepath.Clean(b)
+	}
+
+	return absA == absB
+}
+
+func jsonNode(value interface{}, origin yaml.Origin) yaml.Node {
+	switch val := value.(type) {
+	case map[string]interface{}:
+		nodes := map[string]yaml.Node{}
+		for key, sub := range val {
+			nodes[key] = jsonNode(sub, origin)
+		}
+
+		return yaml.NewNodeWithOrigin(nodes, origin)
+
+	case []interface{}:
+		nodes := make([]yaml.Node, len(val))
+		for i, sub := range val {
+			nodes[i] = jsonNode(sub, origin)
+		}
+
+		return yaml.NewNodeWithOrigin(nodes, origin)
+
+	case json.Number:
+		i, err := val.Int64()
+		if err == nil {
+			return yaml.NewNodeWithOrigin(i, origin)
+		}
+
+		f, _ := val.Float64()
+		return yaml.NewNodeWithOrigin(f, origin)
+	}
+
+	return yaml.NewNodeWithOrigin(value, origin)
+}
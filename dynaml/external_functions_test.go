@@ -0,0 +1,201 @@
+package dynaml
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("external functions", func() {
+	Describe("env(name)", func() {
+		BeforeEach(func() {
+			os.Setenv("SPIFF_TEST_VARIABLE", "some value")
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("SPIFF_TEST_VARIABLE")
+		})
+
+		It("evaluates to the value of the environment variable", func() {
+			expr := call("env", StringExpr{"SPIFF_TEST_VARIABLE"})
+
+			Expect(expr).To(EvaluateAs("some value", FakeBinding{}))
+		})
+
+		It("fails if the environment variable is not set", func() {
+			expr := call("env", StringExpr{"SPIFF_TEST_UNSET_VARIABLE"})
+
+			_, info, ok := expr.Evaluate(FakeBinding{})
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("environment variable SPIFF_TEST_UNSET_VARIABLE is not set"))
+		})
+
+		It("can be given a default", func() {
+			expr := OrExpr{
+				call("env", StringExpr{"SPIFF_TEST_UNSET_VARIABLE"}),
+				StringExpr{"default"},
+			}
+
+			Expect(expr).To(EvaluateAs("default", FakeBinding{}))
+		})
+	})
+
+	Describe("read(path[, type])", func() {
+		var dir string
+		var binding FakeBinding
+
+		write := func(name, contents string) {
+			err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "spiff-read")
+			Expect(err).NotTo(HaveOccurred())
+
+			binding = FakeBinding{
+				SourceOrigin: &yaml.Origin{SourceName: filepath.Join(dir, "template.yml")},
+			}
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("reads YAML relative to the document it is used in", func() {
+			write("other.yml", "foo: (( bar ))\n")
+
+			result, _, ok := call("read", StringExpr{"other.yml"}).Evaluate(binding)
+			Expect(ok).To(BeTrue())
+
+			foo, found := yaml.Find(result, "foo")
+			Expect(found).To(BeTrue())
+			Expect(foo.Value()).To(Equal("(( bar ))"))
+			Expect(foo.SourceName()).To(Equal(filepath.Join(dir, "other.yml")))
+			Expect(foo.Origin().IncludedFrom).To(Equal(binding.SourceOrigin))
+		})
+
+		It("reads JSON", func() {
+			write("other.json", `{"list": [1, 2.5, "three", true, null]}`)
+
+			result, _, ok := call("read", StringExpr{"other.json"}).Evaluate(binding)
+			Expect(ok).To(BeTrue())
+			Expect(result.EquivalentToNode(parseYAML(`
+list: [1, 2.5, three, true, ~]
+`))).To(BeTrue())
+		})
+
+		It("reads anything else as text", func() {
+			write("script.sh", "echo 'foo: bar'\n")
+
+			Expect(call("read", StringExpr{"script.sh"})).To(
+				EvaluateAs("echo 'foo: bar'\n", binding),
+			)
+		})
+
+		It("takes JSON and text as data rather than dynaml", func() {
+			write("other.json", `{"foo": "(( bar ))"}`)
+			write("other.txt", "(( bar ))")
+
+			result, _, ok := call("read", StringExpr{"other.json"}).Evaluate(binding)
+			Expect(ok).To(BeTrue())
+
+			foo, found := yaml.Find(result, "foo")
+			Expect(found).To(BeTrue())
+			Expect(foo.Literal()).To(BeTrue())
+
+			result, _, ok = call("read", StringExpr{"other.txt"}).Evaluate(binding)
+			Expect(ok).To(BeTrue())
+			Expect(result.Value()).To(Equal("(( bar ))"))
+			Expect(result.Literal()).To(BeTrue())
+		})
+
+		It("reads with the given type", func() {
+			write("other.yml", "foo: bar\n")
+
+			Expect(call("read", StringExpr{"other.yml"}, StringExpr{"text"})).To(
+				EvaluateAs("foo: bar\n", binding),
+			)
+		})
+
+		It("fails for unknown types", func() {
+			_, info, ok := call("read", StringExpr{"other.yml"}, StringExpr{"xml"}).Evaluate(binding)
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("read: type must be yaml, json or text, but is 'xml'"))
+		})
+
+		It("fails if the file cannot be read", func() {
+			_, info, ok := call("read", StringExpr{"missing.yml"}).Evaluate(binding)
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(ContainSubstring("read: open " + filepath.Join(dir, "missing.yml")))
+		})
+
+		It("fails on include cycles", func() {
+			template := filepath.Join(dir, "template.yml")
+			other := filepath.Join(dir, "other.yml")
+
+			binding.SourceOrigin = &yaml.Origin{
+				SourceName:   other,
+				IncludedFrom: &yaml.Origin{SourceName: template},
+			}
+
+			_, info, ok := call("read", StringExpr{"template.yml"}).Evaluate(binding)
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("read: include cycle " + template + " -> " + other + " -> " + template))
+		})
+	})
+
+	Describe("exec(command, args...)", func() {
+		var binding FakeBinding
+
+		BeforeEach(func() {
+			binding = FakeBinding{Exec: NewExecCache()}
+		})
+
+		It("parses the output of the command as YAML", func() {
+			result, _, ok := call("exec", StringExpr{"echo"}, StringExpr{"{port: "}, IntegerExpr{8080}, StringExpr{"}"}).Evaluate(binding)
+			Expect(ok).To(BeTrue())
+			Expect(result.EquivalentToNode(parseYAML(`port: 8080`))).To(BeTrue())
+		})
+
+		It("runs each command only once", func() {
+			counter, err := ioutil.TempFile("", "spiff-exec")
+			Expect(err).NotTo(HaveOccurred())
+			counter.Close()
+			defer os.Remove(counter.Name())
+
+			expr := call("exec", StringExpr{"sh"}, StringExpr{"-c"}, StringExpr{"echo run >> " + counter.Name() + "; echo done"})
+
+			Expect(expr).To(EvaluateAs("done", binding))
+			Expect(expr).To(EvaluateAs("done", binding))
+
+			runs, err := ioutil.ReadFile(counter.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(runs)).To(Equal("run\n"))
+		})
+
+		It("fails with the error output of the command", func() {
+			_, info, ok := call("exec", StringExpr{"sh"}, StringExpr{"-c"}, StringExpr{"echo oops >&2; exit 3"}).Evaluate(binding)
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("exec: sh: exit status 3: oops"))
+		})
+
+		It("fails for arguments that are lists or maps", func() {
+			_, info, ok := call("exec", StringExpr{"echo"}, ListExpr{}).Evaluate(binding)
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("exec: argument 2 must be a string, number or bool, but is list"))
+		})
+
+		It("fails unless running commands is enabled", func() {
+			_, info, ok := call("exec", StringExpr{"echo"}, StringExpr{"foo"}).Evaluate(FakeBinding{})
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("exec: running commands is disabled, use spiff merge --enable-exec"))
+		})
+	})
+})
@@ -10,6 +10,17 @@ type FakeBinding struct {
 	FoundFromRoot   map[string]yaml.Node
 	FoundReferences map[string]yaml.Node
 	FoundInStubs    map[string]yaml.Node
+
+	SourceOrigin *yaml.Origin
+	Exec         *ExecCache
+}
+
+func (c FakeBinding) Origin() yaml.Origin {
+	if c.SourceOrigin != nil {
+		return *c.SourceOrigin
+	}
+
+	return yaml.Origin{SourceName: "fake"}
 }
 
 func (c FakeBinding) FindFromRoot(path []string) (yaml.Node, bool) {
@@ -26,3 +37,7 @@ func (c FakeBinding) FindInStubs(path []string) (yaml.Node, bool) {
 	val, found := c.FoundInStubs[strings.Join(path, ".")]
 	return val, found
 }
+
+func (c FakeBinding) ExecCache() *ExecCache {
+	return c.Exec
+}
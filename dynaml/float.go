@@ -0,0 +1,17 @@
+package dynaml
+
+import (
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+type FloatExpr struct {
+	Value float64
+}
+
+func (e FloatExpr) Evaluate(binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	return node(e.Value, binding), DefaultInfo(), true
+}
+
+func (e FloatExpr) String() string {
+	return formatFloat(e.Value)
+}
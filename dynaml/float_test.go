@@ -0,0 +1,12 @@
+package dynaml
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("floats", func() {
+	It("evaluates to a float", func() {
+		Expect(FloatExpr{4.2}).To(EvaluateAs(4.2, FakeBinding{}))
+	})
+})
@@ -0,0 +1,188 @@
+package dynaml
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+// ArgumentType restricts the values a function accepts for an argument.
+type ArgumentType int
+
+const (
+	AnyArgument ArgumentType = iota
+	StringArgument
+	IntArgument
+	NumberArgument
+	BoolArgument
+	ListArgument
+	MapArgument
+	LambdaArgument
+)
+
+func (t ArgumentType) accepts(n yaml.Node) bool {
+	switch n.Value().(type) {
+	case string:
+		return t == AnyArgument || t == StringArgument
+	case int64:
+		return t == AnyArgument || t == IntArgument || t == NumberArgument
+	case float64:
+		return t == AnyArgument || t == NumberArgument
+	case bool:
+		return t == AnyArgument || t == BoolArgument
+	case []yaml.Node:
+		return t == AnyArgument || t == ListArgument
+	case map[string]yaml.Node:
+		return t == AnyArgument || t == MapArgument
+	case LambdaExpr:
+		return t == AnyArgument || t == LambdaArgument
+	}
+
+	return t == AnyArgument
+}
+
+func (t ArgumentType) String() string {
+	switch t {
+	case StringArgument:
+		return "a string"
+	case IntArgument:
+		return "an int"
+	case NumberArgument:
+		return "a number"
+	case BoolArgument:
+		return "a bool"
+	case ListArgument:
+		return "a list"
+	case MapArgument:
+		return "a map"
+	case LambdaArgument:
+		return "a lambda"
+	}
+
+	return "any value"
+}
+
+// Function describes a function that can be called from dynaml, e.g.
+// (( static_ips(0, 1) )).
+//
+// Calls are checked against the argument types before the implementation
+// runs: the last Optional entries of Arguments may be omitted, and a
+// Variadic function accepts any number of further arguments of the last
+// type.
+type Function struct {
+	Name      string
+	Arguments []ArgumentType
+	Optional  int
+	Variadic  bool
+
+	Implementation func(arguments []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool)
+}
+
+func (f Function) check(args []yaml.Node) (EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	min := len(f.Arguments) - f.Optional
+	max := len(f.Arguments)
+
+	if len(args) < min || (!f.Variadic && len(args) > max) {
+		_, info, _ = info.Error("%s: expected %s, got %d", f.Name, f.arity(), len(args))
+		return info, false
+	}
+
+	for i, arg := range args {
+		expected := AnyArgument
+		if i < len(f.Arguments) {
+			expected = f.Arguments[i]
+		} else if len(f.Arguments) > 0 {
+			expected = f.Arguments[len(f.Arguments)-1]
+		}
+
+		if !expected.accepts(arg) {
+			_, info, _ = info.Error("%s: argument %d must be %s, but is %s", f.Name, i+1, expected, typeName(arg))
+			return info, false
+		}
+	}
+
+	return info, true
+}
+
+func (f Function) arity() string {
+	min := len(f.Arguments) - f.Optional
+	max := len(f.Arguments)
+
+	switch {
+	case f.Variadic:
+		return fmt.Sprintf("at least %s", arguments(min))
+	case min == max:
+		return arguments(min)
+	default:
+		return fmt.Sprintf("%d to %s", min, arguments(max))
+	}
+}
+
+func arguments(n int) string {
+	if n == 1 {
+		return "1 argument"
+	}
+
+	return fmt.Sprintf("%d arguments", n)
+}
+
+var functionName = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
+// FunctionRegistry holds the functions available to dynaml calls.
+type FunctionRegistry struct {
+	lock      sync.RWMutex
+	functions map[string]Function
+}
+
+func NewFunctionRegistry() *FunctionRegistry {
+	return &FunctionRegistry{functions: map[string]Function{}}
+}
+
+// Register adds a function to the registry. Like database/sql's Register, it
+// panics if the function is invalid or its name is already taken, as
+// registration is expected to happen while a program starts up.
+func (r *FunctionRegistry) Register(f Function) {
+	if !functionName.MatchString(f.Name) {
+		panic(fmt.Sprintf("dynaml: invalid function name '%s'", f.Name))
+	}
+
+	if f.Implementation == nil {
+		panic(fmt.Sprintf("dynaml: function '%s' has no implementation", f.Name))
+	}
+
+	if f.Optional < 0 || f.Optional > len(f.Arguments) {
+		panic(fmt.Sprintf("dynaml: function '%s' has %d optional of %d arguments", f.Name, f.Optional, len(f.Arguments)))
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, found := r.functions[f.Name]; found {
+		panic(fmt.Sprintf("dynaml: function '%s' is already registered", f.Name))
+	}
+
+	r.functions[f.Name] = f
+}
+
+// Lookup finds a registered function by name.
+func (r *FunctionRegistry) Lookup(name string) (Function, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	f, found := r.functions[name]
+	return f, found
+}
+
+// Functions is the registry used when evaluating calls.
+var Functions = NewFunctionRegistry()
+
+// RegisterFunction makes a function callable from dynaml expressions. To
+// build a spiff binary with additional functions, register them from the main
+// function of your own command before running the application of package app.
+func RegisterFunction(f Function) {
+	Functions.Register(f)
+}
@@ -0,0 +1,104 @@
+package dynaml
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+func double(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	return node(args[0].Value().(int64)*2, binding), DefaultInfo(), true
+}
+
+func init() {
+	RegisterFunction(Function{
+		Name:           "test_double",
+		Arguments:      []ArgumentType{IntArgument},
+		Implementation: double,
+	})
+}
+
+var _ = Describe("function registry", func() {
+	var registry *FunctionRegistry
+
+	BeforeEach(func() {
+		registry = NewFunctionRegistry()
+	})
+
+	It("looks up registered functions", func() {
+		registry.Register(Function{Name: "double", Implementation: double})
+
+		function, found := registry.Lookup("double")
+		Expect(found).To(BeTrue())
+		Expect(function.Name).To(Equal("double"))
+
+		_, found = registry.Lookup("triple")
+		Expect(found).To(BeFalse())
+	})
+
+	It("rejects duplicate names", func() {
+		registry.Register(Function{Name: "double", Implementation: double})
+
+		Expect(func() {
+			registry.Register(Function{Name: "double", Implementation: double})
+		}).To(Panic())
+	})
+
+	It("rejects names that cannot be called", func() {
+		Expect(func() {
+			registry.Register(Function{Name: "dou-ble", Implementation: double})
+		}).To(Panic())
+	})
+
+	It("rejects functions without an implementation", func() {
+		Expect(func() {
+			registry.Register(Function{Name: "double"})
+		}).To(Panic())
+	})
+
+	It("registers static_ips as a builtin", func() {
+		_, found := Functions.Lookup("static_ips")
+		Expect(found).To(BeTrue())
+	})
+
+	Describe("calling a registered function", func() {
+		It("evaluates the implementation", func() {
+			expr := CallExpr{Name: "test_double", Arguments: []Expression{IntegerExpr{21}}}
+
+			Expect(expr).To(EvaluateAs(42, FakeBinding{}))
+		})
+
+		It("checks the number of arguments", func() {
+			expr := CallExpr{Name: "test_double"}
+
+			_, info, ok := expr.Evaluate(FakeBinding{})
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("test_double: expected 1 argument, got 0"))
+		})
+
+		It("checks the types of the arguments", func() {
+			expr := CallExpr{Name: "test_double", Arguments: []Expression{StringExpr{"21"}}}
+
+			_, info, ok := expr.Evaluate(FakeBinding{})
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("test_double: argument 1 must be an int, but is string"))
+		})
+
+		It("describes optional arguments", func() {
+			expr := CallExpr{Name: "substr", Arguments: []Expression{StringExpr{"foo"}}}
+
+			_, info, ok := expr.Evaluate(FakeBinding{})
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("substr: expected 2 to 3 arguments, got 1"))
+		})
+
+		It("checks variadic arguments against the last type", func() {
+			expr := CallExpr{Name: "static_ips", Arguments: []Expression{IntegerExpr{0}, StringExpr{"1"}}}
+
+			_, info, ok := expr.Evaluate(FakeBinding{})
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("static_ips: argument 2 must be an int, but is string"))
+		})
+	})
+})
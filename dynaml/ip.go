@@ -0,0 +1,194 @@
+package dynaml
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+)
+
+// IP addresses are handled as big integers, so that IPv4 and IPv6 ranges can
+// be walked, compared and measured the same way.
+
+func parseIP(s string) (net.IP, error) {
+	ip := net.ParseIP(strings.TrimSpace(s))
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP '%s'", strings.TrimSpace(s))
+	}
+
+	if v4 := ip.To4(); v4 != nil {
+		return v4, nil
+	}
+
+	return ip, nil
+}
+
+func parseCIDR(s string) (*net.IPNet, error) {
+	_, network, err := net.ParseCIDR(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR '%s'", strings.TrimSpace(s))
+	}
+
+	return network, nil
+}
+
+func ipToInt(ip net.IP) *big.Int {
+	return new(big.Int).SetBytes(ip)
+}
+
+func intToIP(n *big.Int, length int) net.IP {
+	bytes := n.Bytes()
+
+	ip := make(net.IP, length)
+	copy(ip[length-len(bytes):], bytes)
+
+	return ip
+}
+
+// ipRange is an inclusive range of addresses of the same family.
+type ipRange struct {
+	start  *big.Int
+	end    *big.Int
+	length int
+}
+
+// parseIPRange parses a single address or a range like
+// "10.0.0.10 - 10.0.0.20".
+func parseIPRange(s string) (ipRange, error) {
+	segments := strings.Split(s, "-")
+	if len(segments) > 2 {
+		return ipRange{}, fmt.Errorf("invalid range '%s'", s)
+	}
+
+	start, err := parseIP(segments[0])
+	if err != nil {
+		return ipRange{}, fmt.Errorf("invalid range '%s': %s", s, err)
+	}
+
+	end := start
+	if len(segments) == 2 {
+		end, err = parseIP(segments[1])
+		if err != nil {
+			return ipRange{}, fmt.Errorf("invalid range '%s': %s", s, err)
+		}
+	}
+
+	if len(start) != len(end) {
+		return ipRange{}, fmt.Errorf("invalid range '%s': mixes IPv4 and IPv6", s)
+	}
+
+	r := ipRange{start: ipToInt(start), end: ipToInt(end), length: len(start)}
+	if r.start.Cmp(r.end) > 0 {
+		return ipRange{}, fmt.Errorf("invalid range '%s': %s is after %s", s, start, end)
+	}
+
+	return r, nil
+}
+
+// cidrRange is the range of all addresses of a network.
+func cidrRange(network *net.IPNet) ipRange {
+	ones, bits := network.Mask.Size()
+
+	start := ipToInt(network.IP.Mask(network.Mask))
+
+	end := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+	end.Add(end, start)
+	end.Sub(end, big.NewInt(1))
+
+	return ipRange{start: start, end: end, length: len(network.IP)}
+}
+
+func (r ipRange) String() string {
+	if r.start.Cmp(r.end) == 0 {
+		return r.first().String()
+	}
+
+	return fmt.Sprintf("%s - %s", r.first(), r.last())
+}
+
+func (r ipRange) first() net.IP {
+	return intToIP(r.start, r.length)
+}
+
+func (r ipRange) last() net.IP {
+	return intToIP(r.end, r.length)
+}
+
+func (r ipRange) size() *big.Int {
+	size := new(big.Int).Sub(r.end, r.start)
+	return size.Add(size, big.NewInt(1))
+}
+
+func (r ipRange) within(network *net.IPNet) bool {
+	return network.Contains(r.first()) && network.Contains(r.last())
+}
+
+// subtract returns the parts of the range not covered by another one.
+func (r ipRange) subtract(other ipRange) []ipRange {
+	if r.length != other.length || other.end.Cmp(r.start) < 0 || other.start.Cmp(r.end) > 0 {
+		return []ipRange{r}
+	}
+
+	rest := []ipRange{}
+
+	if other.start.Cmp(r.start) > 0 {
+		end := new(big.Int).Sub(other.start, big.NewInt(1))
+		rest = append(rest, ipRange{start: r.start, end: end, length: r.length})
+	}
+
+	if other.end.Cmp(r.end) < 0 {
+		start := new(big.Int).Add(other.end, big.NewInt(1))
+		rest = append(rest, ipRange{start: start, end: r.end, length: r.length})
+	}
+
+	return rest
+}
+
+// ipPool is a sequence of ranges whose addresses are numbered consecutively
+// without ever being expanded.
+type ipPool []ipRange
+
+func (p ipPool) size() *big.Int {
+	size := big.NewInt(0)
+	for _, r := range p {
+		size.Add(size, r.size())
+	}
+
+	return size
+}
+
+// at returns the address with the given index in the pool.
+func (p ipPool) at(index int) (net.IP, bool) {
+	if index < 0 {
+		return nil, false
+	}
+
+	offset := big.NewInt(int64(index))
+
+	for _, r := range p {
+		size := r.size()
+		if offset.Cmp(size) < 0 {
+			return intToIP(offset.Add(offset, r.start), r.length), true
+		}
+
+		offset.Sub(offset, size)
+	}
+
+	return nil, false
+}
+
+// without removes the addresses of the given ranges from the pool.
+func (p ipPool) without(excluded []ipRange) ipPool {
+	pool := p
+
+	for _, exclusion := range excluded {
+		rest := ipPool{}
+		for _, r := range pool {
+			rest = append(rest, r.subtract(exclusion)...)
+		}
+
+		pool = rest
+	}
+
+	return pool
+}
@@ -0,0 +1,97 @@
+package dynaml
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IP ranges", func() {
+	mustParse := func(s string) ipRange {
+		r, err := parseIPRange(s)
+		if err != nil {
+			panic(err)
+		}
+
+		return r
+	}
+
+	Describe("parsing", func() {
+		It("parses single addresses", func() {
+			r := mustParse("10.0.0.1")
+			Expect(r.String()).To(Equal("10.0.0.1"))
+			Expect(r.size().Int64()).To(Equal(int64(1)))
+		})
+
+		It("parses ranges", func() {
+			r := mustParse("10.0.0.250 - 10.0.1.4")
+			Expect(r.String()).To(Equal("10.0.0.250 - 10.0.1.4"))
+			Expect(r.size().Int64()).To(Equal(int64(11)))
+		})
+
+		It("parses IPv6 ranges", func() {
+			r := mustParse("2001:db8::ffff - 2001:db8::1:0")
+			Expect(r.size().Int64()).To(Equal(int64(2)))
+		})
+
+		It("rejects ranges mixing IPv4 and IPv6", func() {
+			_, err := parseIPRange("10.0.0.1 - 2001:db8::1")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("rejects ranges with more than two ends", func() {
+			_, err := parseIPRange("10.0.0.1 - 10.0.0.2 - 10.0.0.3")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("rejects ranges starting after their end", func() {
+			_, err := parseIPRange("10.0.0.2 - 10.0.0.1")
+			Expect(err).To(MatchError("invalid range '10.0.0.2 - 10.0.0.1': 10.0.0.2 is after 10.0.0.1"))
+		})
+	})
+
+	Describe("subtracting", func() {
+		r := mustParse("10.0.0.10 - 10.0.0.20")
+
+		It("splits around the subtracted range", func() {
+			rest := r.subtract(mustParse("10.0.0.12 - 10.0.0.14"))
+			Expect(rest).To(HaveLen(2))
+			Expect(rest[0].String()).To(Equal("10.0.0.10 - 10.0.0.11"))
+			Expect(rest[1].String()).To(Equal("10.0.0.15 - 10.0.0.20"))
+		})
+
+		It("cuts off overlapping ends", func() {
+			rest := r.subtract(mustParse("10.0.0.0 - 10.0.0.12"))
+			Expect(rest).To(HaveLen(1))
+			Expect(rest[0].String()).To(Equal("10.0.0.13 - 10.0.0.20"))
+		})
+
+		It("removes covered ranges", func() {
+			Expect(r.subtract(mustParse("10.0.0.0 - 10.0.0.255"))).To(BeEmpty())
+		})
+
+		It("keeps disjoint ranges", func() {
+			Expect(r.subtract(mustParse("10.0.0.21"))).To(Equal([]ipRange{r}))
+		})
+	})
+
+	Describe("pools", func() {
+		pool := ipPool{mustParse("10.0.0.10 - 10.0.0.11"), mustParse("10.0.1.0 - 10.0.1.255")}
+
+		It("numbers the addresses of all ranges", func() {
+			ip, ok := pool.at(2)
+			Expect(ok).To(BeTrue())
+			Expect(ip.String()).To(Equal("10.0.1.0"))
+
+			ip, ok = pool.at(257)
+			Expect(ok).To(BeTrue())
+			Expect(ip.String()).To(Equal("10.0.1.255"))
+		})
+
+		It("has no addresses beyond its size", func() {
+			Expect(pool.size().Int64()).To(Equal(int64(258)))
+
+			_, ok := pool.at(258)
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,55 @@
+package dynaml
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+// LambdaExpr is an anonymous function like |x| -> x.name. Lambdas are passed
+// to functions such as map, which call them for each entry of a list.
+type LambdaExpr struct {
+	Parameters []string
+	Body       Expression
+}
+
+func (e LambdaExpr) Evaluate(binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	return DefaultInfo().Error("lambda expressions can only be passed to functions")
+}
+
+func (e LambdaExpr) String() string {
+	return fmt.Sprintf("|%s| -> %s", strings.Join(e.Parameters, ","), e.Body)
+}
+
+// Call evaluates the body of the lambda with its parameters bound to the
+// given values. References to anything else are resolved by the binding.
+func (e LambdaExpr) Call(binding Binding, args ...yaml.Node) (yaml.Node, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(args) != len(e.Parameters) {
+		return info.Error("lambda %s expects %s, got %d", e, arguments(len(e.Parameters)), len(args))
+	}
+
+	values := map[string]yaml.Node{}
+	for i, parameter := range e.Parameters {
+		values[parameter] = args[i]
+	}
+
+	return e.Body.Evaluate(lambdaBinding{Binding: binding, values: values})
+}
+
+type lambdaBinding struct {
+	Binding
+
+	values map[string]yaml.Node
+}
+
+func (b lambdaBinding) FindReference(path []string) (yaml.Node, bool) {
+	val, found := b.values[path[0]]
+	if found {
+		return yaml.Find(val, path[1:]...)
+	}
+
+	return b.Binding.FindReference(path)
+}
@@ -0,0 +1,47 @@
+package dynaml
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+var _ = Describe("lambdas", func() {
+	lambda := LambdaExpr{
+		Parameters: []string{"x"},
+		Body: ConcatenationExpr{
+			ReferenceExpr{[]string{"prefix"}},
+			ReferenceExpr{[]string{"x", "name"}},
+		},
+	}
+
+	binding := FakeBinding{
+		FoundReferences: map[string]yaml.Node{
+			"prefix": node("cf-", nil),
+		},
+	}
+
+	It("fails to evaluate on their own", func() {
+		Expect(lambda).To(FailToEvaluate(binding))
+	})
+
+	Describe("calling a lambda", func() {
+		It("binds its parameters and resolves other references", func() {
+			result, _, ok := lambda.Call(binding, parseYAML(`name: api`))
+			Expect(ok).To(BeTrue())
+			Expect(result.Value()).To(Equal("cf-api"))
+		})
+
+		It("fails if its parameter cannot be stepped into", func() {
+			_, _, ok := lambda.Call(binding, parseYAML(`other: api`))
+			Expect(ok).To(BeFalse())
+		})
+
+		It("fails on the wrong number of arguments", func() {
+			_, info, ok := lambda.Call(binding)
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("lambda |x| -> prefix x.name expects 1 argument, got 0"))
+		})
+	})
+})
@@ -11,19 +11,19 @@ type ListExpr struct {
 	Contents []Expression
 }
 
-func (e ListExpr) Evaluate(binding Binding) (yaml.Node, bool) {
+func (e ListExpr) Evaluate(binding Binding) (yaml.Node, EvaluationInfo, bool) {
 	nodes := []yaml.Node{}
 
 	for _, c := range e.Contents {
-		result, ok := c.Evaluate(binding)
+		result, info, ok := c.Evaluate(binding)
 		if !ok {
-			return nil, false
+			return nil, info, false
 		}
 
 		nodes = append(nodes, result)
 	}
 
-	return node(nodes), true
+	return node(nodes, binding), DefaultInfo(), true
 }
 
 func (e ListExpr) String() string {
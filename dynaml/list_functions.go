@@ -0,0 +1,221 @@
+package dynaml
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+func init() {
+	RegisterFunction(Function{
+		Name:           "map",
+		Arguments:      []ArgumentType{ListArgument, LambdaArgument},
+		Implementation: funcMap,
+	})
+
+	RegisterFunction(Function{
+		Name:           "filter",
+		Arguments:      []ArgumentType{ListArgument, LambdaArgument},
+		Implementation: funcFilter,
+	})
+
+	RegisterFunction(Function{
+		Name:           "sum",
+		Arguments:      []ArgumentType{ListArgument, LambdaArgument},
+		Optional:       1,
+		Implementation: funcSum,
+	})
+
+	RegisterFunction(Function{
+		Name:           "min",
+		Arguments:      []ArgumentType{ListArgument, LambdaArgument},
+		Optional:       1,
+		Implementation: extremum("min", -1),
+	})
+
+	RegisterFunction(Function{
+		Name:           "max",
+		Arguments:      []ArgumentType{ListArgument, LambdaArgument},
+		Optional:       1,
+		Implementation: extremum("max", 1),
+	})
+
+	RegisterFunction(Function{
+		Name:           "sort",
+		Arguments:      []ArgumentType{ListArgument, LambdaArgument},
+		Optional:       1,
+		Implementation: funcSort,
+	})
+}
+
+// map[list|x|->expr] evaluates the lambda for each entry of the list.
+func funcMap(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	values, info, ok := project("map", args, binding)
+	if !ok {
+		return nil, info, false
+	}
+
+	return node(values, binding), info, true
+}
+
+// filter[list|x|->cond] keeps the entries for which the lambda is true.
+func funcFilter(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	list := args[0].Value().([]yaml.Node)
+
+	conditions, info, ok := project("filter", args, binding)
+	if !ok {
+		return nil, info, false
+	}
+
+	result := []yaml.Node{}
+	for i, cond := range conditions {
+		keep, ok := cond.Value().(bool)
+		if !ok {
+			return info.Error("filter: lambda must return a bool, but returned %s for entry %d", typeName(cond), i)
+		}
+
+		if keep {
+			result = append(result, list[i])
+		}
+	}
+
+	return node(result, binding), info, true
+}
+
+// sum(list) and sum[list|x|->expr] add up numbers. The sum is an int unless
+// one of the numbers is a float.
+func funcSum(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	values, info, ok := project("sum", args, binding)
+	if !ok {
+		return nil, info, false
+	}
+
+	var intSum int64
+	var floatSum float64
+	isFloat := false
+
+	for i, val := range values {
+		switch v := val.Value().(type) {
+		case int64:
+			intSum += v
+			floatSum += float64(v)
+		case float64:
+			floatSum += v
+			isFloat = true
+		default:
+			return info.Error("sum: entry %d must be a number, but is %s", i, typeName(val))
+		}
+	}
+
+	if isFloat {
+		return node(floatSum, binding), info, true
+	}
+
+	return node(intSum, binding), info, true
+}
+
+// extremum builds min and max, which return the smallest or largest of a
+// list of numbers or strings.
+func extremum(name string, sign int) func([]yaml.Node, Binding) (yaml.Node, EvaluationInfo, bool) {
+	return func(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+		values, info, ok := project(name, args, binding)
+		if !ok {
+			return nil, info, false
+		}
+
+		if len(values) == 0 {
+			return info.Error("%s: list is empty", name)
+		}
+
+		result := values[0]
+		for _, val := range values[1:] {
+			order, ok := compareValues(val, result)
+			if !ok {
+				return info.Error("%s: cannot compare %s and %s", name, typeName(val), typeName(result))
+			}
+
+			if order == sign {
+				result = val
+			}
+		}
+
+		return result, info, true
+	}
+}
+
+// sort(list) sorts numbers or strings; sort[list|x|->key] sorts the entries
+// of a list by the key computed for each of them.
+func funcSort(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	list := args[0].Value().([]yaml.Node)
+
+	keys, info, ok := project("sort", args, binding)
+	if !ok {
+		return nil, info, false
+	}
+
+	indices := make([]int, len(list))
+	for i := range indices {
+		indices[i] = i
+	}
+
+	var issue string
+	sort.SliceStable(indices, func(i, j int) bool {
+		a, b := keys[indices[i]], keys[indices[j]]
+
+		order, ok := compareValues(a, b)
+		if !ok && issue == "" {
+			issue = fmt.Sprintf("sort: cannot compare %s and %s", typeName(a), typeName(b))
+		}
+
+		return order < 0
+	})
+
+	if issue != "" {
+		return info.Error("%s", issue)
+	}
+
+	sorted := make([]yaml.Node, len(list))
+	for i, index := range indices {
+		sorted[i] = list[index]
+	}
+
+	return node(sorted, binding), info, true
+}
+
+// project calls the optional lambda of a list function for each entry of the
+// list. Without a lambda, the entries are returned as they are.
+func project(name string, args []yaml.Node, binding Binding) ([]yaml.Node, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	list := args[0].Value().([]yaml.Node)
+	if len(args) == 1 {
+		for i, entry := range list {
+			if isExpression(entry) {
+				info.Issue = fmt.Sprintf("%s: entry %d is not resolved yet", name, i)
+				return nil, info, false
+			}
+		}
+
+		return list, info, true
+	}
+
+	lambda := args[1].Value().(LambdaExpr)
+
+	values := make([]yaml.Node, len(list))
+	for i, entry := range list {
+		val, info, ok := lambda.Call(binding, entry)
+		if !ok {
+			return nil, info, false
+		}
+
+		if isExpression(val) {
+			info.Issue = fmt.Sprintf("%s: %s", name, info.Issue)
+			return nil, info, false
+		}
+
+		values[i] = val
+	}
+
+	return values, info, true
+}
@@ -0,0 +1,152 @@
+package dynaml
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+var _ = Describe("list functions", func() {
+	binding := FakeBinding{
+		FoundReferences: map[string]yaml.Node{
+			"jobs": parseYAML(`
+- name: router
+  instances: 3
+  az: z1
+- name: api
+  instances: 2
+  az: z2
+- name: nats
+  instances: 1
+  az: z1
+`),
+		},
+	}
+
+	jobs := ReferenceExpr{[]string{"jobs"}}
+
+	field := func(name string) LambdaExpr {
+		return LambdaExpr{[]string{"x"}, ReferenceExpr{[]string{"x", name}}}
+	}
+
+	names := func(list yaml.Node) []string {
+		result := []string{}
+		for _, entry := range list.Value().([]yaml.Node) {
+			name, _ := yaml.FindString(entry, "name")
+			result = append(result, name)
+		}
+
+		return result
+	}
+
+	Describe("map[list|x|->expr]", func() {
+		It("evaluates the lambda for each entry", func() {
+			expr := call("map", jobs, field("name"))
+
+			Expect(expr).To(EvaluateAs([]yaml.Node{
+				node("router", nil),
+				node("api", nil),
+				node("nats", nil),
+			}, binding))
+		})
+
+		It("fails if the lambda fails", func() {
+			Expect(call("map", jobs, field("missing"))).To(FailToEvaluate(binding))
+		})
+
+		It("requires a lambda", func() {
+			_, info, ok := call("map", jobs, IntegerExpr{1}).Evaluate(binding)
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("map: argument 2 must be a lambda, but is int"))
+		})
+	})
+
+	Describe("filter[list|x|->cond]", func() {
+		It("keeps the entries the lambda is true for", func() {
+			expr := call("filter", jobs, LambdaExpr{
+				[]string{"x"},
+				EqualExpr{ReferenceExpr{[]string{"x", "az"}}, StringExpr{"z1"}},
+			})
+
+			result, _, ok := expr.Evaluate(binding)
+			Expect(ok).To(BeTrue())
+			Expect(names(result)).To(Equal([]string{"router", "nats"}))
+		})
+
+		It("fails if the lambda does not return a bool", func() {
+			_, info, ok := call("filter", jobs, field("name")).Evaluate(binding)
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("filter: lambda must return a bool, but returned string for entry 0"))
+		})
+	})
+
+	Describe("sum(list)", func() {
+		It("adds up ints", func() {
+			Expect(call("sum", jobs, field("instances"))).To(EvaluateAs(6, binding))
+		})
+
+		It("adds up floats", func() {
+			expr := call("sum", ListExpr{[]Expression{IntegerExpr{1}, FloatExpr{0.5}}})
+
+			Expect(expr).To(EvaluateAs(1.5, binding))
+		})
+
+		It("returns 0 for empty lists", func() {
+			Expect(call("sum", ListExpr{})).To(EvaluateAs(0, binding))
+		})
+
+		It("fails on non-numbers", func() {
+			_, info, ok := call("sum", jobs, field("name")).Evaluate(binding)
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("sum: entry 0 must be a number, but is string"))
+		})
+	})
+
+	Describe("min(list) and max(list)", func() {
+		It("find the smallest and largest value", func() {
+			Expect(call("min", jobs, field("instances"))).To(EvaluateAs(1, binding))
+			Expect(call("max", jobs, field("instances"))).To(EvaluateAs(3, binding))
+		})
+
+		It("compare strings", func() {
+			Expect(call("min", jobs, field("name"))).To(EvaluateAs("api", binding))
+		})
+
+		It("fail on empty lists", func() {
+			_, info, ok := call("max", ListExpr{}).Evaluate(binding)
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("max: list is empty"))
+		})
+
+		It("fail on values that cannot be compared", func() {
+			expr := call("min", ListExpr{[]Expression{IntegerExpr{1}, StringExpr{"a"}}})
+
+			Expect(expr).To(FailToEvaluate(binding))
+		})
+	})
+
+	Describe("sort(list)", func() {
+		It("sorts numbers", func() {
+			expr := call("sort", ListExpr{[]Expression{IntegerExpr{3}, FloatExpr{1.5}, IntegerExpr{2}}})
+
+			Expect(expr).To(EvaluateAs([]yaml.Node{node(1.5, nil), node(2, nil), node(3, nil)}, binding))
+		})
+
+		It("sorts entries by the key computed by the lambda", func() {
+			result, _, ok := call("sort", jobs, field("name")).Evaluate(binding)
+			Expect(ok).To(BeTrue())
+			Expect(names(result)).To(Equal([]string{"api", "nats", "router"}))
+		})
+
+		It("keeps the order of entries with equal keys", func() {
+			result, _, ok := call("sort", jobs, field("az")).Evaluate(binding)
+			Expect(ok).To(BeTrue())
+			Expect(names(result)).To(Equal([]string{"router", "nats", "api"}))
+		})
+
+		It("fails on values that cannot be compared", func() {
+			Expect(call("sort", jobs)).To(FailToEvaluate(binding))
+		})
+	})
+})
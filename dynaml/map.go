@@ -0,0 +1,44 @@
+package dynaml
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+type MapExpr struct {
+	Contents map[string]Expression
+}
+
+func (e MapExpr) Evaluate(binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	nodes := map[string]yaml.Node{}
+
+	for key, c := range e.Contents {
+		result, info, ok := c.Evaluate(binding)
+		if !ok {
+			return nil, info, false
+		}
+
+		nodes[key] = result
+	}
+
+	return node(nodes, binding), DefaultInfo(), true
+}
+
+func (e MapExpr) String() string {
+	keys := make([]string, 0, len(e.Contents))
+	for key := range e.Contents {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	entries := make([]string, len(keys))
+	for i, key := range keys {
+		entries[i] = fmt.Sprintf("%q: %s", key, e.Contents[key])
+	}
+
+	return fmt.Sprintf("{%s}", strings.Join(entries, ", "))
+}
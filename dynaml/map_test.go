@@ -0,0 +1,42 @@
+package dynaml
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+var _ = Describe("maps", func() {
+	It("evaluates to a map of nodes", func() {
+		expr := MapExpr{
+			map[string]Expression{
+				"port": IntegerExpr{8080},
+				"tls":  BooleanExpr{false},
+			},
+		}
+
+		Expect(expr).To(EvaluateAs(map[string]yaml.Node{
+			"port": node(8080, nil),
+			"tls":  node(false, nil),
+		}, FakeBinding{}))
+	})
+
+	Context("when empty", func() {
+		It("evaluates to an empty map", func() {
+			Expect(MapExpr{}).To(EvaluateAs(map[string]yaml.Node{}, FakeBinding{}))
+		})
+	})
+
+	Context("when an entry does not resolve", func() {
+		It("fails", func() {
+			expr := MapExpr{
+				map[string]Expression{
+					"foo": ReferenceExpr{[]string{"foo"}},
+				},
+			}
+
+			Expect(expr).To(FailToEvaluate(FakeBinding{}))
+		})
+	})
+})
@@ -1,17 +1,60 @@
 package dynaml
 
 import (
+	"strings"
+
 	"github.com/cloudfoundry-incubator/spiff/yaml"
 )
 
+// MergeExpr takes the value at its path from the stubs. The path is the one
+// of the node the expression appears in, unless it is redirected to another
+// one, as in (( merge other.path )).
+//
+// Replace, None and KeyName only matter for "<<": the merged value replaces
+// the template's own entries, or no value is merged in and the stubs are kept
+// from overriding anything below the node. KeyName is the field identifying
+// the entries of a merged list, as in (( merge on key )).
 type MergeExpr struct {
-	Path []string
+	Path     []string
+	Redirect bool
+	Replace  bool
+	None     bool
+	KeyName  string
 }
 
-func (e MergeExpr) Evaluate(binding Binding) (yaml.Node, bool) {
-	return binding.FindInStubs(e.Path)
+func (e MergeExpr) Evaluate(binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if e.None {
+		return info.Error("merge none can only be used with <<")
+	}
+
+	val, found := binding.FindInStubs(e.Path)
+	if !found {
+		return info.Error("%s not found in any stub", strings.Join(e.Path, "."))
+	}
+
+	return val, info, true
 }
 
 func (e MergeExpr) String() string {
-	return "merge"
+	str := "merge"
+
+	if e.None {
+		str += " none"
+	}
+
+	if e.Replace {
+		str += " replace"
+	}
+
+	if e.KeyName != "" {
+		str += " on " + e.KeyName
+	}
+
+	if e.Redirect {
+		str += " " + strings.Join(e.Path, ".")
+	}
+
+	return str
 }
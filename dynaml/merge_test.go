@@ -12,11 +12,11 @@ var _ = Describe("merges", func() {
 		It("evaluates to the merged node", func() {
 			referencedNode := IntegerExpr{42}
 
-			expr := MergeExpr{[]string{"foo", "bar"}}
+			expr := MergeExpr{Path: []string{"foo", "bar"}}
 
 			binding := FakeBinding{
 				FoundInStubs: map[string]yaml.Node{
-					"foo.bar": node(referencedNode),
+					"foo.bar": node(referencedNode, nil),
 				},
 			}
 
@@ -28,15 +28,59 @@ var _ = Describe("merges", func() {
 		It("fails", func() {
 			referencedNode := IntegerExpr{42}
 
-			expr := MergeExpr{[]string{"foo", "bar", "baz"}}
+			expr := MergeExpr{Path: []string{"foo", "bar", "baz"}}
 
 			binding := FakeBinding{
 				FoundInStubs: map[string]yaml.Node{
-					"foo.bar": node(referencedNode),
+					"foo.bar": node(referencedNode, nil),
 				},
 			}
 
 			Expect(expr).To(FailToEvaluate(binding))
 		})
+
+		It("reports the missing path", func() {
+			expr := MergeExpr{Path: []string{"foo", "bar", "baz"}, Redirect: true}
+
+			_, info, _ := expr.Evaluate(FakeBinding{})
+			Expect(info.Issue).To(Equal("foo.bar.baz not found in any stub"))
+		})
+
+		It("reports the missing path of the node", func() {
+			expr := MergeExpr{Path: []string{"foo", "bar", "baz"}}
+
+			_, info, _ := expr.Evaluate(FakeBinding{})
+			Expect(info.Issue).To(Equal("foo.bar.baz not found in any stub"))
+		})
+	})
+
+	Context("when merging none", func() {
+		It("fails, as it only applies to <<", func() {
+			expr := MergeExpr{Path: []string{"foo", "bar"}, None: true}
+
+			binding := FakeBinding{
+				FoundInStubs: map[string]yaml.Node{
+					"foo.bar": node(IntegerExpr{42}, nil),
+				},
+			}
+
+			_, info, ok := expr.Evaluate(binding)
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("merge none can only be used with <<"))
+		})
+	})
+
+	Describe("printing", func() {
+		It("includes redirected paths", func() {
+			Expect(MergeExpr{Path: []string{"foo", "bar"}}.String()).To(Equal("merge"))
+			Expect(MergeExpr{Path: []string{"foo", "bar"}, Redirect: true}.String()).To(Equal("merge foo.bar"))
+		})
+
+		It("includes the merge mode", func() {
+			Expect(MergeExpr{Path: []string{"foo"}, None: true}.String()).To(Equal("merge none"))
+			Expect(MergeExpr{Path: []string{"foo"}, Replace: true}.String()).To(Equal("merge replace"))
+			Expect(MergeExpr{Path: []string{"foo"}, Replace: true, Redirect: true}.String()).To(Equal("merge replace foo"))
+			Expect(MergeExpr{Path: []string{"foo"}, KeyName: "key"}.String()).To(Equal("merge on key"))
+		})
 	})
 })
@@ -0,0 +1,57 @@
+package dynaml
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+type ModuloExpr struct {
+	A Expression
+	B Expression
+}
+
+func (e ModuloExpr) Evaluate(binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	a, info, ok := e.A.Evaluate(binding)
+	if !ok {
+		return nil, info, false
+	}
+
+	if isExpression(a) {
+		return unresolvedOperand(e, info, binding)
+	}
+
+	b, info, ok := e.B.Evaluate(binding)
+	if !ok {
+		return nil, info, false
+	}
+
+	if isExpression(b) {
+		return unresolvedOperand(e, info, binding)
+	}
+
+	aint, bint, ok := asInts(a, b)
+	if ok {
+		if bint == 0 {
+			return info.Error("modulo by zero")
+		}
+
+		return node(aint%bint, binding), info, true
+	}
+
+	afloat, bfloat, ok := asFloats(a, b)
+	if !ok {
+		return info.Error("cannot compute %s modulo %s", typeName(a), typeName(b))
+	}
+
+	if bfloat == 0 {
+		return info.Error("modulo by zero")
+	}
+
+	return node(math.Mod(afloat, bfloat), binding), info, true
+}
+
+func (e ModuloExpr) String() string {
+	return fmt.Sprintf("%s %% %s", e.A, e.B)
+}
@@ -0,0 +1,69 @@
+package dynaml
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("modulo", func() {
+	It("computes the remainder", func() {
+		expr := ModuloExpr{
+			IntegerExpr{7},
+			IntegerExpr{3},
+		}
+
+		Expect(expr).To(EvaluateAs(1, FakeBinding{}))
+	})
+
+	Context("when either side is a float", func() {
+		It("computes the floating-point remainder", func() {
+			Expect(ModuloExpr{FloatExpr{7.5}, IntegerExpr{2}}).To(EvaluateAs(1.5, FakeBinding{}))
+		})
+	})
+
+	Context("when the left-hand side is not an integer", func() {
+		It("fails", func() {
+			expr := ModuloExpr{
+				StringExpr{"lol"},
+				IntegerExpr{2},
+			}
+
+			Expect(expr).To(FailToEvaluate(FakeBinding{}))
+		})
+
+		It("reports the mismatching types", func() {
+			expr := ModuloExpr{
+				StringExpr{"lol"},
+				IntegerExpr{2},
+			}
+
+			_, info, _ := expr.Evaluate(FakeBinding{})
+			Expect(info.Issue).To(Equal("cannot compute string modulo int"))
+		})
+	})
+
+	Context("when the right-hand side is not an integer", func() {
+		It("fails", func() {
+			expr := ModuloExpr{
+				IntegerExpr{2},
+				StringExpr{"lol"},
+			}
+
+			Expect(expr).To(FailToEvaluate(FakeBinding{}))
+		})
+	})
+
+	Context("when the right-hand side is zero", func() {
+		It("fails with a clear reason", func() {
+			expr := ModuloExpr{
+				IntegerExpr{2},
+				IntegerExpr{0},
+			}
+
+			Expect(expr).To(FailToEvaluate(FakeBinding{}))
+
+			_, info, _ := expr.Evaluate(FakeBinding{})
+			Expect(info.Issue).To(Equal("modulo by zero"))
+		})
+	})
+})
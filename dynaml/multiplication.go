@@ -0,0 +1,48 @@
+package dynaml
+
+import (
+	"fmt"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+type MultiplicationExpr struct {
+	A Expression
+	B Expression
+}
+
+func (e MultiplicationExpr) Evaluate(binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	a, info, ok := e.A.Evaluate(binding)
+	if !ok {
+		return nil, info, false
+	}
+
+	if isExpression(a) {
+		return unresolvedOperand(e, info, binding)
+	}
+
+	b, info, ok := e.B.Evaluate(binding)
+	if !ok {
+		return nil, info, false
+	}
+
+	if isExpression(b) {
+		return unresolvedOperand(e, info, binding)
+	}
+
+	aint, bint, ok := asInts(a, b)
+	if ok {
+		return node(aint*bint, binding), info, true
+	}
+
+	afloat, bfloat, ok := asFloats(a, b)
+	if !ok {
+		return info.Error("cannot multiply %s by %s", typeName(a), typeName(b))
+	}
+
+	return node(afloat*bfloat, binding), info, true
+}
+
+func (e MultiplicationExpr) String() string {
+	return fmt.Sprintf("%s * %s", e.A, e.B)
+}
@@ -0,0 +1,77 @@
+package dynaml
+
+import (
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("multiplication", func() {
+	It("multiplies both numbers", func() {
+		expr := MultiplicationExpr{
+			IntegerExpr{6},
+			IntegerExpr{7},
+		}
+
+		Expect(expr).To(EvaluateAs(42, FakeBinding{}))
+	})
+
+	Context("when either side is a float", func() {
+		It("multiplies both as floats", func() {
+			Expect(MultiplicationExpr{IntegerExpr{3}, FloatExpr{0.5}}).To(EvaluateAs(1.5, FakeBinding{}))
+		})
+	})
+
+	Context("when the left-hand side is not an integer", func() {
+		It("fails", func() {
+			expr := MultiplicationExpr{
+				StringExpr{"lol"},
+				IntegerExpr{2},
+			}
+
+			Expect(expr).To(FailToEvaluate(FakeBinding{}))
+		})
+
+		It("reports the mismatching types", func() {
+			expr := MultiplicationExpr{
+				StringExpr{"lol"},
+				IntegerExpr{2},
+			}
+
+			_, info, _ := expr.Evaluate(FakeBinding{})
+			Expect(info.Issue).To(Equal("cannot multiply string by int"))
+		})
+	})
+
+	Context("when the right-hand side is not an integer", func() {
+		It("fails", func() {
+			expr := MultiplicationExpr{
+				IntegerExpr{2},
+				StringExpr{"lol"},
+			}
+
+			Expect(expr).To(FailToEvaluate(FakeBinding{}))
+		})
+	})
+
+	Context("when an operand is not resolved yet", func() {
+		It("stays unresolved, passing on why", func() {
+			expr := MultiplicationExpr{
+				ReferenceExpr{[]string{"foo"}},
+				IntegerExpr{2},
+			}
+
+			binding := FakeBinding{
+				FoundReferences: map[string]yaml.Node{
+					"foo": node(ReferenceExpr{[]string{"bar"}}, nil),
+				},
+			}
+
+			result, info, ok := expr.Evaluate(binding)
+			Expect(ok).To(BeTrue())
+			Expect(result.Value()).To(Equal(expr))
+			Expect(info.Issue).To(Equal("foo is not resolved yet"))
+		})
+	})
+})
@@ -0,0 +1,499 @@
+package dynaml
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+func init() {
+	RegisterFunction(Function{
+		Name:           "static_ips",
+		Arguments:      []ArgumentType{IntArgument},
+		Optional:       1,
+		Variadic:       true,
+		Implementation: funcStaticIPs,
+	})
+
+	RegisterFunction(Function{
+		Name:           "striped_static_ips",
+		Arguments:      []ArgumentType{StringArgument, AnyArgument},
+		Optional:       1,
+		Implementation: funcStripedStaticIPs,
+	})
+
+	RegisterFunction(Function{
+		Name:           "dynamic_ips",
+		Arguments:      []ArgumentType{StringArgument},
+		Optional:       1,
+		Implementation: funcDynamicIPs,
+	})
+
+	RegisterFunction(Function{
+		Name:           "min_ip",
+		Arguments:      []ArgumentType{StringArgument},
+		Implementation: funcMinIP,
+	})
+
+	RegisterFunction(Function{
+		Name:           "max_ip",
+		Arguments:      []ArgumentType{StringArgument},
+		Implementation: funcMaxIP,
+	})
+
+	RegisterFunction(Function{
+		Name:           "num_ip",
+		Arguments:      []ArgumentType{StringArgument},
+		Implementation: funcNumIP,
+	})
+
+	RegisterFunction(Function{
+		Name:           "ip_offset",
+		Arguments:      []ArgumentType{StringArgument, IntArgument},
+		Implementation: funcIPOffset,
+	})
+
+	RegisterFunction(Function{
+		Name:           "cidr_subnet",
+		Arguments:      []ArgumentType{StringArgument, IntArgument, IntArgument},
+		Implementation: funcCIDRSubnet,
+	})
+}
+
+func funcStaticIPs(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	indices := make([]int, len(args))
+	for i, arg := range args {
+		indices[i] = int(arg.Value().(int64))
+	}
+
+	return generateStaticIPs(binding, indices)
+}
+
+func generateStaticIPs(binding Binding, indices []int) (yaml.Node, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(indices) == 0 {
+		return info.Error("static_ips: no indices given")
+	}
+
+	ipPool, issue, ok := findStaticIPPool(binding)
+	if !ok {
+		return info.Error("static_ips: %s", issue)
+	}
+
+	instanceCount, issue, ok := findInstanceCount(binding)
+	if !ok {
+		return info.Error("static_ips: %s", issue)
+	}
+
+	ips := []yaml.Node{}
+	for _, i := range indices {
+		ip, ok := ipPool.at(i)
+		if !ok {
+			return info.Error("static_ips: index %d out of range, only %s static IPs available", i, ipPool.size())
+		}
+
+		ips = append(ips, node(ip.String(), binding))
+	}
+
+	if len(ips) < instanceCount {
+		return info.Error("static_ips: only %d IPs given for %d instances", len(ips), instanceCount)
+	}
+
+	return node(ips[:instanceCount], binding), info, true
+}
+
+// striped_static_ips(network) and striped_static_ips(network, offsets) assign
+// static IPs of the named network to the instances of the nearest job,
+// spreading them across the subnets round-robin. The offsets skip static IPs
+// at the start of each subnet; they are given as a single int for all
+// subnets or as a list with an int for each subnet.
+func funcStripedStaticIPs(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	networkName := args[0].Value().(string)
+
+	subnets, issue, ok := findSubnets(binding, networkName)
+	if !ok {
+		return info.Error("striped_static_ips: %s", issue)
+	}
+
+	if len(subnets) == 0 {
+		return info.Error("striped_static_ips: network %s has no subnets", networkName)
+	}
+
+	offsets := make([]int, len(subnets))
+	if len(args) == 2 {
+		switch val := args[1].Value().(type) {
+		case int64:
+			for i := range offsets {
+				offsets[i] = int(val)
+			}
+		case []yaml.Node:
+			if len(val) != len(subnets) {
+				return info.Error("striped_static_ips: %d offsets given for %d subnets of network %s", len(val), len(subnets), networkName)
+			}
+
+			for i, offset := range val {
+				offsetInt, ok := offset.Value().(int64)
+				if !ok {
+					return info.Error("striped_static_ips: offset %d must be an int, but is %s", i, typeName(offset))
+				}
+
+				offsets[i] = int(offsetInt)
+			}
+		default:
+			return info.Error("striped_static_ips: offsets must be an int or a list, but are %s", typeName(args[1]))
+		}
+	}
+
+	instanceCount, issue, ok := findInstanceCount(binding)
+	if !ok {
+		return info.Error("striped_static_ips: %s", issue)
+	}
+
+	pools := make([]ipPool, len(subnets))
+	for i, subnet := range subnets {
+		pools[i] = subnet.static.without(subnet.reserved)
+	}
+
+	ips := []yaml.Node{}
+	for instance := 0; instance < instanceCount; instance++ {
+		s := instance % len(subnets)
+		index := offsets[s] + instance/len(subnets)
+
+		ip, ok := pools[s].at(index)
+		if !ok {
+			return info.Error("striped_static_ips: index %d out of range, subnet %d of network %s has only %s static IPs", index, s, networkName, pools[s].size())
+		}
+
+		ips = append(ips, node(ip.String(), binding))
+	}
+
+	return node(ips, binding), info, true
+}
+
+// dynamic_ips() and dynamic_ips(network) list the ranges of the network's
+// subnets that are neither static nor reserved. The network, its broadcast
+// address and the gateway of each subnet are left out as well.
+func funcDynamicIPs(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	var networkName string
+	if len(args) == 1 {
+		networkName = args[0].Value().(string)
+	} else {
+		name, issue, ok := findNetworkName(binding)
+		if !ok {
+			return info.Error("dynamic_ips: %s", issue)
+		}
+
+		networkName = name
+	}
+
+	subnets, issue, ok := findSubnets(binding, networkName)
+	if !ok {
+		return info.Error("dynamic_ips: %s", issue)
+	}
+
+	ranges := []yaml.Node{}
+
+	for _, subnet := range subnets {
+		if subnet.network == nil {
+			return info.Error("dynamic_ips: subnet %d of network %s has no range", subnet.index, networkName)
+		}
+
+		whole := cidrRange(subnet.network)
+
+		excluded := append(ipPool{}, subnet.static...)
+		excluded = append(excluded, subnet.reserved...)
+		excluded = append(excluded, ipRange{start: whole.start, end: whole.start, length: whole.length})
+
+		if whole.length == net.IPv4len {
+			excluded = append(excluded, ipRange{start: whole.end, end: whole.end, length: whole.length})
+		}
+
+		if subnet.gateway != nil {
+			gateway := ipToInt(subnet.gateway)
+			excluded = append(excluded, ipRange{start: gateway, end: gateway, length: len(subnet.gateway)})
+		}
+
+		for _, r := range (ipPool{whole}).without(excluded) {
+			ranges = append(ranges, node(r.String(), binding))
+		}
+	}
+
+	return node(ranges, binding), info, true
+}
+
+// min_ip(cidr) is the first address of a network.
+func funcMinIP(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	network, err := parseCIDR(args[0].Value().(string))
+	if err != nil {
+		return DefaultInfo().Error("min_ip: %s", err)
+	}
+
+	return node(cidrRange(network).first().String(), binding), DefaultInfo(), true
+}
+
+// max_ip(cidr) is the last address of a network.
+func funcMaxIP(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	network, err := parseCIDR(args[0].Value().(string))
+	if err != nil {
+		return DefaultInfo().Error("max_ip: %s", err)
+	}
+
+	return node(cidrRange(network).last().String(), binding), DefaultInfo(), true
+}
+
+// num_ip(cidr) is the number of addresses of a network.
+func funcNumIP(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	network, err := parseCIDR(args[0].Value().(string))
+	if err != nil {
+		return info.Error("num_ip: %s", err)
+	}
+
+	size := cidrRange(network).size()
+	if !size.IsInt64() {
+		return info.Error("num_ip: %s has too many addresses to count", network)
+	}
+
+	return node(size.Int64(), binding), info, true
+}
+
+// ip_offset(ip, n) is the address n addresses after (or, if n is negative,
+// before) the given one.
+func funcIPOffset(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	ip, err := parseIP(args[0].Value().(string))
+	if err != nil {
+		return info.Error("ip_offset: %s", err)
+	}
+
+	offset := args[1].Value().(int64)
+
+	n := new(big.Int).Add(ipToInt(ip), big.NewInt(offset))
+	if n.Sign() < 0 || n.BitLen() > len(ip)*8 {
+		return info.Error("ip_offset: %s %+d is not a valid address", ip, offset)
+	}
+
+	return node(intToIP(n, len(ip)).String(), binding), info, true
+}
+
+// cidr_subnet(cidr, bits, index) carves the network into subnets with a
+// prefix that is longer by the given number of bits, and returns the one with
+// the given index.
+func funcCIDRSubnet(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	network, err := parseCIDR(args[0].Value().(string))
+	if err != nil {
+		return info.Error("cidr_subnet: %s", err)
+	}
+
+	bits := args[1].Value().(int64)
+	index := args[2].Value().(int64)
+
+	prefix, size := network.Mask.Size()
+	if bits < 0 || int64(prefix)+bits > int64(size) {
+		return info.Error("cidr_subnet: cannot extend the prefix of %s by %d bits", network, bits)
+	}
+
+	newPrefix := prefix + int(bits)
+
+	count := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	if index < 0 || big.NewInt(index).Cmp(count) >= 0 {
+		return info.Error("cidr_subnet: index %d out of range, %s has %s subnets with prefix /%d", index, network, count, newPrefix)
+	}
+
+	start := new(big.Int).Lsh(big.NewInt(index), uint(size-newPrefix))
+	start.Add(start, ipToInt(network.IP))
+
+	subnet := net.IPNet{
+		IP:   intToIP(start, len(network.IP)),
+		Mask: net.CIDRMask(newPrefix, size),
+	}
+
+	return node(subnet.String(), binding), info, true
+}
+
+func findInstanceCount(binding Binding) (int, string, bool) {
+	nearestInstances, found := binding.FindReference([]string{"instances"})
+	if !found {
+		return 0, "instances not found", false
+	}
+
+	instances, ok := nearestInstances.Value().(int64)
+	if !ok {
+		return 0, "instances must be an int, but is " + typeName(nearestInstances), false
+	}
+
+	return int(instances), "", true
+}
+
+func findNetworkName(binding Binding) (string, string, bool) {
+	nearestNetworkName, found := binding.FindReference([]string{"name"})
+	if !found {
+		return "", "network name not found", false
+	}
+
+	networkName, ok := nearestNetworkName.Value().(string)
+	if !ok {
+		return "", "network name must be a string, but is " + typeName(nearestNetworkName), false
+	}
+
+	return networkName, "", true
+}
+
+// findStaticIPPool collects the static ranges of the subnets of the nearest
+// network, leaving out their reserved ranges.
+func findStaticIPPool(binding Binding) (ipPool, string, bool) {
+	networkName, issue, ok := findNetworkName(binding)
+	if !ok {
+		return nil, issue, false
+	}
+
+	subnets, issue, ok := findSubnets(binding, networkName)
+	if !ok {
+		return nil, issue, false
+	}
+
+	pool := ipPool{}
+
+	for _, subnet := range subnets {
+		if subnet.static == nil {
+			return nil, fmt.Sprintf("subnet %d of network %s has no static ranges", subnet.index, networkName), false
+		}
+
+		pool = append(pool, subnet.static.without(subnet.reserved)...)
+	}
+
+	return pool, "", true
+}
+
+type subnet struct {
+	index int
+
+	network  *net.IPNet
+	gateway  net.IP
+	static   ipPool
+	reserved ipPool
+}
+
+// findSubnets parses the subnets of a network. Static ranges must lie within
+// the subnet's range, if one is given.
+func findSubnets(binding Binding, networkName string) ([]subnet, string, bool) {
+	subnets, found := binding.FindFromRoot(
+		[]string{"networks", networkName, "subnets"},
+	)
+
+	if !found {
+		return nil, fmt.Sprintf("networks.%s.subnets not found", networkName), false
+	}
+
+	subnetsList, ok := subnets.Value().([]yaml.Node)
+	if !ok {
+		return nil, fmt.Sprintf("networks.%s.subnets must be a list, but is %s", networkName, typeName(subnets)), false
+	}
+
+	result := []subnet{}
+
+	for i, entry := range subnetsList {
+		subnetMap, ok := entry.Value().(map[string]yaml.Node)
+		if !ok {
+			return nil, fmt.Sprintf("subnet %d of network %s must be a map", i, networkName), false
+		}
+
+		s := subnet{index: i}
+
+		static, ok := subnetMap["static"]
+		if ok {
+			pool, err := parseIPRanges(static)
+			if err != nil {
+				return nil, fmt.Sprintf("static ranges of subnet %d of network %s: %s", i, networkName, err), false
+			}
+
+			s.static = pool
+		}
+
+		reserved, ok := subnetMap["reserved"]
+		if ok {
+			pool, err := parseIPRanges(reserved)
+			if err != nil {
+				return nil, fmt.Sprintf("reserved ranges of subnet %d of network %s: %s", i, networkName, err), false
+			}
+
+			s.reserved = pool
+		}
+
+		gateway, ok := subnetMap["gateway"]
+		if ok {
+			gatewayString, ok := gateway.Value().(string)
+			if !ok {
+				return nil, fmt.Sprintf("gateway of subnet %d of network %s must be a string, but is %s", i, networkName, typeName(gateway)), false
+			}
+
+			ip, err := parseIP(gatewayString)
+			if err != nil {
+				return nil, fmt.Sprintf("gateway of subnet %d of network %s: %s", i, networkName, err), false
+			}
+
+			s.gateway = ip
+		}
+
+		cidr, ok := subnetMap["range"]
+		if ok {
+			cidrString, ok := cidr.Value().(string)
+			if !ok {
+				return nil, fmt.Sprintf("range of subnet %d of network %s must be a string, but is %s", i, networkName, typeName(cidr)), false
+			}
+
+			network, err := parseCIDR(cidrString)
+			if err != nil {
+				return nil, fmt.Sprintf("range of subnet %d of network %s: %s", i, networkName, err), false
+			}
+
+			for _, r := range s.static {
+				if !r.within(network) {
+					return nil, fmt.Sprintf("static range %s of subnet %d of network %s is not within %s", r, i, networkName, network), false
+				}
+			}
+
+			s.network = network
+		}
+
+		result = append(result, s)
+	}
+
+	return result, "", true
+}
+
+func parseIPRanges(ranges yaml.Node) (ipPool, error) {
+	rangeList, ok := ranges.Value().([]yaml.Node)
+	if !ok {
+		return nil, fmt.Errorf("must be a list, but is %s", typeName(ranges))
+	}
+
+	pool := ipPool{}
+
+	for _, r := range rangeList {
+		rangeString, ok := r.Value().(string)
+		if !ok {
+			return nil, fmt.Errorf("range must be a string, but is %s", typeName(r))
+		}
+
+		parsed, err := parseIPRange(rangeString)
+		if err != nil {
+			return nil, err
+		}
+
+		pool = append(pool, parsed)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,441 @@
+package dynaml
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+var _ = Describe("network functions", func() {
+	Describe("static_ips(ips...)", func() {
+		expr := CallExpr{
+			Name: "static_ips",
+			Arguments: []Expression{
+				IntegerExpr{0},
+				IntegerExpr{4},
+			},
+		}
+
+		It("returns a set of ips from the given network's subnets", func() {
+			subnets := parseYAML(`
+- static:
+    - 10.10.16.10
+- static:
+    - 10.10.16.11 - 10.10.16.254
+`)
+
+			binding := FakeBinding{
+				FoundReferences: map[string]yaml.Node{
+					"name":      node("cf1", nil),
+					"instances": node(2, nil),
+				},
+				FoundFromRoot: map[string]yaml.Node{
+					"networks.cf1.subnets": subnets,
+				},
+			}
+
+			Expect(expr).To(
+				EvaluateAs(
+					[]yaml.Node{node("10.10.16.10", nil), node("10.10.16.14", nil)},
+					binding,
+				),
+			)
+		})
+
+		It("limits the IPs to the number of instances", func() {
+			subnets := parseYAML(`
+- static:
+    - 10.10.16.10 - 10.10.16.254
+`)
+
+			binding := FakeBinding{
+				FoundReferences: map[string]yaml.Node{
+					"name":      node("cf1", nil),
+					"instances": node(1, nil),
+				},
+				FoundFromRoot: map[string]yaml.Node{
+					"networks.cf1.subnets": subnets,
+				},
+			}
+
+			Expect(expr).To(
+				EvaluateAs(
+					[]yaml.Node{node("10.10.16.10", nil)},
+					binding,
+				),
+			)
+		})
+
+		Context("when the instance count is dynamic", func() {
+			It("fails", func() {
+				subnets := parseYAML(`
+- static:
+    - 10.10.16.10 - 10.10.16.254
+`)
+
+				binding := FakeBinding{
+					FoundReferences: map[string]yaml.Node{
+						"name":      node("cf1", nil),
+						"instances": node(MergeExpr{}, nil),
+					},
+					FoundFromRoot: map[string]yaml.Node{
+						"networks.cf1.subnets": subnets,
+					},
+				}
+
+				Expect(expr).To(FailToEvaluate(binding))
+			})
+		})
+
+		Context("when there are not enough IPs for the number of instances", func() {
+			It("fails", func() {
+				subnets := parseYAML(`
+- static:
+    - 10.10.16.10 - 10.10.16.32
+`)
+
+				binding := FakeBinding{
+					FoundReferences: map[string]yaml.Node{
+						"name":      node("cf1", nil),
+						"instances": node(42, nil),
+					},
+					FoundFromRoot: map[string]yaml.Node{
+						"networks.cf1.subnets": subnets,
+					},
+				}
+
+				Expect(expr).To(FailToEvaluate(binding))
+			})
+		})
+
+		Context("when there are singular static IPs listed", func() {
+			It("includes them in the pool", func() {
+				subnets := parseYAML(`
+- static:
+    - 10.10.16.10 - 10.10.16.32
+    - 10.10.16.33
+    - 10.10.16.34
+`)
+
+				expr := CallExpr{
+					Name: "static_ips",
+					Arguments: []Expression{
+						IntegerExpr{0},
+						IntegerExpr{4},
+						IntegerExpr{23},
+					},
+				}
+
+				binding := FakeBinding{
+					FoundReferences: map[string]yaml.Node{
+						"name":      node("cf1", nil),
+						"instances": node(3, nil),
+					},
+					FoundFromRoot: map[string]yaml.Node{
+						"networks.cf1.subnets": subnets,
+					},
+				}
+
+				Expect(expr).To(
+					EvaluateAs(
+						[]yaml.Node{node("10.10.16.10", nil), node("10.10.16.14", nil), node("10.10.16.33", nil)},
+						binding,
+					),
+				)
+			})
+		})
+
+		networkBinding := func(instances int, subnets string) FakeBinding {
+			return FakeBinding{
+				FoundReferences: map[string]yaml.Node{
+					"name":      node("cf1", nil),
+					"instances": node(instances, nil),
+				},
+				FoundFromRoot: map[string]yaml.Node{
+					"networks.cf1.subnets": parseYAML(subnets),
+				},
+			}
+		}
+
+		Context("when a subnet has reserved ranges", func() {
+			It("leaves them out of the pool", func() {
+				binding := networkBinding(2, `
+- range: 10.10.16.0/24
+  reserved:
+    - 10.10.16.11 - 10.10.16.13
+  static:
+    - 10.10.16.10 - 10.10.16.20
+`)
+
+				Expect(expr).To(
+					EvaluateAs(
+						[]yaml.Node{node("10.10.16.10", nil), node("10.10.16.17", nil)},
+						binding,
+					),
+				)
+			})
+		})
+
+		Context("when a static range exceeds the subnet's range", func() {
+			It("fails", func() {
+				binding := networkBinding(2, `
+- range: 10.10.16.0/28
+  static:
+    - 10.10.16.10 - 10.10.16.20
+`)
+
+				_, info, ok := expr.Evaluate(binding)
+				Expect(ok).To(BeFalse())
+				Expect(info.Issue).To(Equal("static_ips: static range 10.10.16.10 - 10.10.16.20 of subnet 0 of network cf1 is not within 10.10.16.0/28"))
+			})
+		})
+
+		Context("when a static range starts after its end", func() {
+			It("fails", func() {
+				binding := networkBinding(2, `
+- static:
+    - 10.10.16.20 - 10.10.16.10
+`)
+
+				_, info, ok := expr.Evaluate(binding)
+				Expect(ok).To(BeFalse())
+				Expect(info.Issue).To(Equal("static_ips: static ranges of subnet 0 of network cf1: invalid range '10.10.16.20 - 10.10.16.10': 10.10.16.20 is after 10.10.16.10"))
+			})
+		})
+
+		Context("when a static range is malformed", func() {
+			It("fails", func() {
+				binding := networkBinding(2, `
+- static:
+    - 10.10.16.10 - 10.10.16
+`)
+
+				_, info, ok := expr.Evaluate(binding)
+				Expect(ok).To(BeFalse())
+				Expect(info.Issue).To(Equal("static_ips: static ranges of subnet 0 of network cf1: invalid range '10.10.16.10 - 10.10.16': invalid IP '10.10.16'"))
+			})
+		})
+
+		Context("when the subnet range is malformed", func() {
+			It("fails", func() {
+				binding := networkBinding(2, `
+- range: 10.10.16.0/33
+  static:
+    - 10.10.16.10 - 10.10.16.20
+`)
+
+				Expect(expr).To(FailToEvaluate(binding))
+			})
+		})
+
+		Context("when the network is IPv6", func() {
+			It("counts across byte boundaries", func() {
+				binding := networkBinding(2, `
+- range: 2001:db8::/64
+  static:
+    - 2001:db8::fe - 2001:db8::1:ff
+`)
+
+				Expect(expr).To(
+					EvaluateAs(
+						[]yaml.Node{node("2001:db8::fe", nil), node("2001:db8::102", nil)},
+						binding,
+					),
+				)
+			})
+		})
+
+		Context("when the static range is large", func() {
+			It("does not need to expand it", func() {
+				expr := CallExpr{
+					Name:      "static_ips",
+					Arguments: []Expression{IntegerExpr{65000}},
+				}
+
+				binding := networkBinding(1, `
+- range: 2001:db8::/32
+  static:
+    - "2001:db8:: - 2001:db8:ffff:ffff:ffff:ffff:ffff:ffff"
+`)
+
+				Expect(expr).To(EvaluateAs([]yaml.Node{node("2001:db8::fde8", nil)}, binding))
+			})
+		})
+	})
+
+	Describe("striped_static_ips(network, offsets)", func() {
+		binding := func(instances int) FakeBinding {
+			return FakeBinding{
+				FoundReferences: map[string]yaml.Node{
+					"name":      node("not-the-network", nil),
+					"instances": node(instances, nil),
+				},
+				FoundFromRoot: map[string]yaml.Node{
+					"networks.cf1.subnets": parseYAML(`
+- range: 10.10.16.0/24
+  reserved:
+    - 10.10.16.11
+  static:
+    - 10.10.16.10 - 10.10.16.14
+- range: 10.10.17.0/24
+  static:
+    - 10.10.17.10 - 10.10.17.14
+`),
+				},
+			}
+		}
+
+		It("spreads the instances across the subnets of the named network", func() {
+			expr := call("striped_static_ips", StringExpr{"cf1"})
+
+			Expect(expr).To(EvaluateAs([]yaml.Node{
+				node("10.10.16.10", nil),
+				node("10.10.17.10", nil),
+				node("10.10.16.12", nil),
+			}, binding(3)))
+		})
+
+		It("skips the same number of static IPs in each subnet", func() {
+			expr := call("striped_static_ips", StringExpr{"cf1"}, IntegerExpr{1})
+
+			Expect(expr).To(EvaluateAs([]yaml.Node{
+				node("10.10.16.12", nil),
+				node("10.10.17.11", nil),
+			}, binding(2)))
+		})
+
+		It("skips a number of static IPs given for each subnet", func() {
+			expr := call("striped_static_ips",
+				StringExpr{"cf1"},
+				ListExpr{[]Expression{IntegerExpr{0}, IntegerExpr{3}}},
+			)
+
+			Expect(expr).To(EvaluateAs([]yaml.Node{
+				node("10.10.16.10", nil),
+				node("10.10.17.13", nil),
+			}, binding(2)))
+		})
+
+		It("fails if there is not an offset for each subnet", func() {
+			expr := call("striped_static_ips",
+				StringExpr{"cf1"},
+				ListExpr{[]Expression{IntegerExpr{0}}},
+			)
+
+			_, info, ok := expr.Evaluate(binding(2))
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("striped_static_ips: 1 offsets given for 2 subnets of network cf1"))
+		})
+
+		It("fails if a subnet runs out of static IPs", func() {
+			expr := call("striped_static_ips", StringExpr{"cf1"})
+
+			_, info, ok := expr.Evaluate(binding(9))
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("striped_static_ips: index 4 out of range, subnet 0 of network cf1 has only 4 static IPs"))
+		})
+
+		It("fails if the network does not exist", func() {
+			Expect(call("striped_static_ips", StringExpr{"cf2"})).To(FailToEvaluate(binding(1)))
+		})
+	})
+
+	Describe("dynamic_ips(network)", func() {
+		binding := FakeBinding{
+			FoundReferences: map[string]yaml.Node{
+				"name": node("cf1", nil),
+			},
+			FoundFromRoot: map[string]yaml.Node{
+				"networks.cf1.subnets": parseYAML(`
+- range: 10.10.16.0/24
+  gateway: 10.10.16.1
+  reserved:
+    - 10.10.16.2 - 10.10.16.9
+  static:
+    - 10.10.16.10 - 10.10.16.100
+- range: 10.10.17.0/24
+  gateway: 10.10.17.1
+`),
+				"networks.cf2.subnets": parseYAML(`
+- static:
+    - 10.10.18.10
+`),
+			},
+		}
+
+		It("lists the ranges that are neither static nor reserved", func() {
+			expr := call("dynamic_ips", StringExpr{"cf1"})
+
+			Expect(expr).To(EvaluateAs([]yaml.Node{
+				node("10.10.16.101 - 10.10.16.254", nil),
+				node("10.10.17.2 - 10.10.17.254", nil),
+			}, binding))
+		})
+
+		It("defaults to the nearest network", func() {
+			result, _, ok := call("dynamic_ips").Evaluate(binding)
+			Expect(ok).To(BeTrue())
+			Expect(result.Value()).To(HaveLen(2))
+		})
+
+		It("fails for subnets without a range", func() {
+			_, info, ok := call("dynamic_ips", StringExpr{"cf2"}).Evaluate(binding)
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("dynamic_ips: subnet 0 of network cf2 has no range"))
+		})
+	})
+
+	Describe("min_ip(cidr), max_ip(cidr) and num_ip(cidr)", func() {
+		It("return the bounds and size of the network", func() {
+			Expect(call("min_ip", StringExpr{"10.10.16.17/20"})).To(EvaluateAs("10.10.16.0", FakeBinding{}))
+			Expect(call("max_ip", StringExpr{"10.10.16.17/20"})).To(EvaluateAs("10.10.31.255", FakeBinding{}))
+			Expect(call("num_ip", StringExpr{"10.10.16.17/20"})).To(EvaluateAs(4096, FakeBinding{}))
+		})
+
+		It("support IPv6", func() {
+			Expect(call("max_ip", StringExpr{"2001:db8::/120"})).To(EvaluateAs("2001:db8::ff", FakeBinding{}))
+		})
+
+		It("fail on networks too large to count", func() {
+			Expect(call("num_ip", StringExpr{"2001:db8::/32"})).To(FailToEvaluate(FakeBinding{}))
+		})
+
+		It("fail on malformed networks", func() {
+			_, info, ok := call("min_ip", StringExpr{"10.10.16.0"}).Evaluate(FakeBinding{})
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("min_ip: invalid CIDR '10.10.16.0'"))
+		})
+	})
+
+	Describe("ip_offset(ip, n)", func() {
+		It("adds the offset to the address", func() {
+			Expect(call("ip_offset", StringExpr{"10.10.16.250"}, IntegerExpr{10})).To(EvaluateAs("10.10.17.4", FakeBinding{}))
+			Expect(call("ip_offset", StringExpr{"10.10.16.0"}, IntegerExpr{-1})).To(EvaluateAs("10.10.15.255", FakeBinding{}))
+			Expect(call("ip_offset", StringExpr{"2001:db8::ffff"}, IntegerExpr{1})).To(EvaluateAs("2001:db8::1:0", FakeBinding{}))
+		})
+
+		It("fails beyond the address space", func() {
+			Expect(call("ip_offset", StringExpr{"255.255.255.255"}, IntegerExpr{1})).To(FailToEvaluate(FakeBinding{}))
+			Expect(call("ip_offset", StringExpr{"0.0.0.0"}, IntegerExpr{-1})).To(FailToEvaluate(FakeBinding{}))
+		})
+	})
+
+	Describe("cidr_subnet(cidr, bits, index)", func() {
+		It("returns the subnet with the given index", func() {
+			Expect(call("cidr_subnet", StringExpr{"10.10.0.0/16"}, IntegerExpr{4}, IntegerExpr{0})).To(EvaluateAs("10.10.0.0/20", FakeBinding{}))
+			Expect(call("cidr_subnet", StringExpr{"10.10.0.0/16"}, IntegerExpr{4}, IntegerExpr{2})).To(EvaluateAs("10.10.32.0/20", FakeBinding{}))
+			Expect(call("cidr_subnet", StringExpr{"2001:db8::/32"}, IntegerExpr{16}, IntegerExpr{3})).To(EvaluateAs("2001:db8:3::/48", FakeBinding{}))
+		})
+
+		It("fails if the index is out of range", func() {
+			_, info, ok := call("cidr_subnet", StringExpr{"10.10.0.0/16"}, IntegerExpr{2}, IntegerExpr{4}).Evaluate(FakeBinding{})
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("cidr_subnet: index 4 out of range, 10.10.0.0/16 has 4 subnets with prefix /18"))
+		})
+
+		It("fails if the prefix gets too long", func() {
+			Expect(call("cidr_subnet", StringExpr{"10.10.0.0/16"}, IntegerExpr{17}, IntegerExpr{0})).To(FailToEvaluate(FakeBinding{}))
+		})
+	})
+})
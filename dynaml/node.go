@@ -4,6 +4,12 @@ import (
 	"github.com/cloudfoundry-incubator/spiff/yaml"
 )
 
-func node(val interface{}) yaml.Node {
-	return yaml.NewNode(val, "dynaml")
+// node creates a node for a value computed by an expression; it originates
+// from wherever the expression being evaluated was defined.
+func node(val interface{}, binding Binding) yaml.Node {
+	if binding == nil {
+		return yaml.NewNode(val, "dynaml")
+	}
+
+	return yaml.NewNodeWithOrigin(val, binding.Origin())
 }
@@ -0,0 +1,33 @@
+package dynaml
+
+import (
+	"fmt"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+type NotExpr struct {
+	Expr Expression
+}
+
+func (e NotExpr) Evaluate(binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	val, info, ok := e.Expr.Evaluate(binding)
+	if !ok {
+		return nil, info, false
+	}
+
+	if isExpression(val) {
+		return unresolvedOperand(e, info, binding)
+	}
+
+	b, ok := val.Value().(bool)
+	if !ok {
+		return info.Error("operand of ! must be a bool, but is %s", typeName(val))
+	}
+
+	return node(!b, binding), info, true
+}
+
+func (e NotExpr) String() string {
+	return fmt.Sprintf("!%s", e.Expr)
+}
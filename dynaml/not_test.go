@@ -0,0 +1,25 @@
+package dynaml
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("not", func() {
+	It("negates a bool", func() {
+		Expect(NotExpr{BooleanExpr{true}}).To(EvaluateAs(false, FakeBinding{}))
+		Expect(NotExpr{BooleanExpr{false}}).To(EvaluateAs(true, FakeBinding{}))
+	})
+
+	Context("when the operand is not a bool", func() {
+		It("fails", func() {
+			Expect(NotExpr{StringExpr{"lol"}}).To(FailToEvaluate(FakeBinding{}))
+		})
+	})
+
+	Context("when the operand fails", func() {
+		It("fails", func() {
+			Expect(NotExpr{FailingExpr{}}).To(FailToEvaluate(FakeBinding{}))
+		})
+	})
+})
@@ -0,0 +1,54 @@
+package dynaml
+
+import (
+	"strconv"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+// Arithmetic stays within integers as long as both operands are ints; as
+// soon as one of them is a float, both are promoted to float64.
+
+func asInts(a, b yaml.Node) (int64, int64, bool) {
+	aint, ok := a.Value().(int64)
+	if !ok {
+		return 0, 0, false
+	}
+
+	bint, ok := b.Value().(int64)
+	if !ok {
+		return 0, 0, false
+	}
+
+	return aint, bint, true
+}
+
+func asFloats(a, b yaml.Node) (float64, float64, bool) {
+	afloat, ok := asFloat(a)
+	if !ok {
+		return 0, 0, false
+	}
+
+	bfloat, ok := asFloat(b)
+	if !ok {
+		return 0, 0, false
+	}
+
+	return afloat, bfloat, true
+}
+
+func asFloat(n yaml.Node) (float64, bool) {
+	switch val := n.Value().(type) {
+	case int64:
+		return float64(val), true
+	case float64:
+		return val, true
+	}
+
+	return 0, false
+}
+
+// formatFloat renders floats the same way they end up in YAML output.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
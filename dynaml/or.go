@@ -12,14 +12,14 @@ type OrExpr struct {
 	B Expression
 }
 
-func (e OrExpr) Evaluate(binding Binding) (yaml.Node, bool) {
-	a, ok := e.A.Evaluate(binding)
+func (e OrExpr) Evaluate(binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	a, info, ok := e.A.Evaluate(binding)
 	if ok {
 		if reflect.DeepEqual(a.Value(), e.A) {
-			return nil, false
+			return nil, info, false
 		}
 
-		return a, true
+		return a, info, true
 	}
 
 	return e.B.Evaluate(binding)
@@ -29,8 +29,39 @@ func buildExpression(grammar *DynamlGrammar, path []string) Expression {
 			return tokens.Pop()
 		case RuleAuto:
 			tokens.Push(AutoExpr{path})
+		case RuleDelete:
+			tokens.Push(DeleteExpr{})
+		case RuleTemporary:
+			if strings.TrimSpace(contents) == "&temporary" {
+				tokens.Push(TemporaryExpr{})
+			} else {
+				tokens.Push(TemporaryExpr{tokens.Pop()})
+			}
 		case RuleMerge:
-			tokens.Push(MergeExpr{path})
+			merge := MergeExpr{Path: path}
+
+			fields := strings.Fields(contents)[1:]
+			if len(fields) > 0 && fields[0] == "none" {
+				merge.None = true
+				fields = fields[1:]
+			}
+
+			if len(fields) > 0 && fields[0] == "replace" {
+				merge.Replace = true
+				fields = fields[1:]
+			}
+
+			if len(fields) > 1 && fields[0] == "on" {
+				merge.KeyName = fields[1]
+				fields = fields[2:]
+			}
+
+			if len(fields) > 0 {
+				merge.Path = strings.Split(fields[0], ".")
+				merge.Redirect = true
+			}
+
+			tokens.Push(merge)
 		case RuleReference:
 			tokens.Push(ReferenceExpr{strings.Split(contents, ".")})
 		case RuleInteger:
@@ -40,6 +71,13 @@ func buildExpression(grammar *DynamlGrammar, path []string) Expression {
 			}
 
 			tokens.Push(IntegerExpr{val})
+		case RuleFloat:
+			val, err := strconv.ParseFloat(contents, 64)
+			if err != nil {
+				panic(err)
+			}
+
+			tokens.Push(FloatExpr{val})
 		case RuleNil:
 			tokens.Push(NilExpr{})
 		case RuleBoolean:
@@ -47,11 +85,43 @@ func buildExpression(grammar *DynamlGrammar, path []string) Expression {
 		case RuleString:
 			val := strings.Replace(contents[1:len(contents)-1], `\"`, `"`, -1)
 			tokens.Push(StringExpr{val})
+		case RuleConditional:
+			b := tokens.Pop()
+			a := tokens.Pop()
+			cond := tokens.Pop()
+
+			tokens.Push(ConditionalExpr{Condition: cond, A: a, B: b})
 		case RuleOr:
 			rhs := tokens.Pop()
 			lhs := tokens.Pop()
 
 			tokens.Push(OrExpr{A: lhs, B: rhs})
+		case RuleAnd:
+			rhs := tokens.Pop()
+			lhs := tokens.Pop()
+
+			tokens.Push(AndExpr{A: lhs, B: rhs})
+		case RuleEqual:
+			rhs := tokens.Pop()
+			lhs := tokens.Pop()
+
+			tokens.Push(EqualExpr{A: lhs, B: rhs})
+		case RuleNotEqual:
+			rhs := tokens.Pop()
+			lhs := tokens.Pop()
+
+			tokens.Push(NotEqualExpr{A: lhs, B: rhs})
+		case RuleLess, RuleLessOrEqual, RuleGreater, RuleGreaterOrEqual:
+			rhs := tokens.Pop()
+			lhs := tokens.Pop()
+
+			tokens.Push(ComparisonExpr{
+				Operator: strings.Fields(contents)[0],
+				A:        lhs,
+				B:        rhs,
+			})
+		case RuleNot:
+			tokens.Push(NotExpr{tokens.Pop()})
 		case RuleConcatenation:
 			rhs := tokens.Pop()
 			lhs := tokens.Pop()
@@ -67,21 +137,68 @@ func buildExpression(grammar *DynamlGrammar, path []string) Expression {
 			lhs := tokens.Pop()
 
 			tokens.Push(SubtractionExpr{A: lhs, B: rhs})
+		case RuleMultiplication:
+			rhs := tokens.Pop()
+			lhs := tokens.Pop()
+
+			tokens.Push(MultiplicationExpr{A: lhs, B: rhs})
+		case RuleDivision:
+			rhs := tokens.Pop()
+			lhs := tokens.Pop()
+
+			tokens.Push(DivisionExpr{A: lhs, B: rhs})
+		case RuleModulo:
+			rhs := tokens.Pop()
+			lhs := tokens.Pop()
+
+			tokens.Push(ModuloExpr{A: lhs, B: rhs})
 		case RuleCall:
+			seq := tokens.EndSeq()
+
 			tokens.Push(CallExpr{
-				Name:      tokens.functionName,
-				Arguments: tokens.PopSeq(),
+				Name:      seq.functionName,
+				Arguments: seq.elements,
 			})
 		case RuleName:
-			tokens.functionName = contents
+			tokens.StartSeq(contents)
+		case RuleProjection:
+			lambda := tokens.Pop()
+			list := tokens.Pop()
+
+			tokens.PushToSeq(list)
+			tokens.PushToSeq(lambda)
+		case RuleLambda:
+			parameters := strings.Split(contents[1:strings.Index(contents[1:], "|")+1], ",")
+			for i, parameter := range parameters {
+				parameters[i] = strings.TrimSpace(parameter)
+			}
+
+			tokens.Push(LambdaExpr{Parameters: parameters, Body: tokens.Pop()})
+		case RuleStartList, RuleStartMap:
+			tokens.StartSeq("")
 		case RuleList:
-			seq := tokens.PopSeq()
-			tokens.Push(ListExpr{seq})
+			seq := tokens.EndSeq()
+			tokens.Push(ListExpr{seq.elements})
 		case RuleComma, RuleContents, RuleArguments:
 			expr := tokens.Pop()
 			tokens.PushToSeq(expr)
+		case RuleMap:
+			seq := tokens.EndSeq()
+			tokens.Push(MapExpr{seq.entries})
+		case RuleKey:
+			if !strings.HasPrefix(contents, `"`) {
+				tokens.Push(StringExpr{contents})
+			}
+		case RuleAssignment:
+			val := tokens.Pop()
+			key := tokens.Pop().(StringExpr)
+
+			tokens.AssignInSeq(key.Value, val)
+		case RuleAssignments:
+		case RuleArgument, RuleParameters, RuleParameter:
+		case RuleMergePath, RuleMergeNone, RuleMergeReplace, RuleMergeOn, RuleMergeKey:
 		case RuleGrouped:
-		case RuleLevel0, RuleLevel1, RuleLevel2:
+		case RuleLevel0, RuleLevel1, RuleLevel2, RuleLevel3, RuleLevel4, RuleLevel5, RuleLevel6:
 		case RuleExpression:
 		case Rulews:
 		case Rulereq_ws:
@@ -96,9 +213,16 @@ func buildExpression(grammar *DynamlGrammar, path []string) Expression {
 type tokenStack struct {
 	list.List
 
-	seq []Expression
+	seqs []*sequence
+}
 
+// sequence collects the arguments of a call or the contents of a list or
+// map literal. They can be nested, so sequences are kept on a stack.
+type sequence struct {
 	functionName string
+
+	elements []Expression
+	entries  map[string]Expression
 }
 
 func (s *tokenStack) Pop() Expression {
@@ -116,16 +240,27 @@ func (s *tokenStack) Push(expr Expression) {
 	s.PushFront(expr)
 }
 
+func (s *tokenStack) StartSeq(functionName string) {
+	s.seqs = append(s.seqs, &sequence{functionName: functionName})
+}
+
 func (s *tokenStack) PushToSeq(expr Expression) {
-	if s.seq == nil {
-		s.seq = []Expression{}
+	seq := s.seqs[len(s.seqs)-1]
+	seq.elements = append(seq.elements, expr)
+}
+
+func (s *tokenStack) AssignInSeq(key string, expr Expression) {
+	seq := s.seqs[len(s.seqs)-1]
+
+	if seq.entries == nil {
+		seq.entries = map[string]Expression{}
 	}
 
-	s.seq = append(s.seq, expr)
+	seq.entries[key] = expr
 }
 
-func (s *tokenStack) PopSeq() []Expression {
-	seq := s.seq
-	s.seq = nil
+func (s *tokenStack) EndSeq() *sequence {
+	seq := s.seqs[len(s.seqs)-1]
+	s.seqs = s.seqs[:len(s.seqs)-1]
 	return seq
 }
@@ -16,6 +16,18 @@ var _ = Describe("parsing", func() {
 		})
 	})
 
+	Describe("floats", func() {
+		It("parses numbers with a fraction", func() {
+			parsesAs("1.5", FloatExpr{1.5})
+			parsesAs("-0.25", FloatExpr{-0.25})
+		})
+
+		It("parses exponents", func() {
+			parsesAs("1.5e3", FloatExpr{1500})
+			parsesAs("2.0E-1", FloatExpr{0.2})
+		})
+	})
+
 	Describe("strings", func() {
 		It("parses strings with escaped quotes", func() {
 			parsesAs(`"foo \"bar\" baz"`, StringExpr{`foo "bar" baz`})
@@ -37,7 +49,61 @@ var _ = Describe("parsing", func() {
 
 	Describe("merge", func() {
 		It("parses as a merge node with the given path", func() {
-			parsesAs("merge", MergeExpr{[]string{"foo", "bar"}}, "foo", "bar")
+			parsesAs("merge", MergeExpr{Path: []string{"foo", "bar"}}, "foo", "bar")
+		})
+
+		It("parses as a merge node redirected to another path", func() {
+			parsesAs(
+				"merge alice.bob.[1]",
+				MergeExpr{Path: []string{"alice", "bob", "[1]"}, Redirect: true},
+				"foo", "bar",
+			)
+		})
+
+		It("parses a redirected merge as an operand", func() {
+			parsesAs(
+				"merge alice || nil",
+				OrExpr{
+					MergeExpr{Path: []string{"alice"}, Redirect: true},
+					NilExpr{},
+				},
+				"foo", "bar",
+			)
+		})
+
+		It("parses merges that replace or keep the template's entries", func() {
+			parsesAs("merge replace", MergeExpr{Path: []string{"foo"}, Replace: true}, "foo")
+			parsesAs("merge none", MergeExpr{Path: []string{"foo"}, None: true}, "foo")
+			parsesAs(
+				"merge replace alice.bob",
+				MergeExpr{Path: []string{"alice", "bob"}, Redirect: true, Replace: true},
+				"foo",
+			)
+		})
+
+		It("parses merges on a key", func() {
+			parsesAs("merge on key", MergeExpr{Path: []string{"foo"}, KeyName: "key"}, "foo")
+			parsesAs(
+				"merge replace on key alice.bob",
+				MergeExpr{Path: []string{"alice", "bob"}, Redirect: true, Replace: true, KeyName: "key"},
+				"foo",
+			)
+			parsesAs(
+				"merge on || nil",
+				OrExpr{
+					MergeExpr{Path: []string{"on"}, Redirect: true},
+					NilExpr{},
+				},
+				"foo",
+			)
+		})
+
+		It("parses paths starting with a merge mode as redirected merges", func() {
+			parsesAs(
+				"merge replacement.none",
+				MergeExpr{Path: []string{"replacement", "none"}, Redirect: true},
+				"foo",
+			)
 		})
 	})
 
@@ -47,6 +113,40 @@ var _ = Describe("parsing", func() {
 		})
 	})
 
+	Describe("delete", func() {
+		It("parses as a delete node", func() {
+			parsesAs("delete", DeleteExpr{})
+		})
+
+		It("parses names starting with delete as references", func() {
+			parsesAs("deleted.foo", ReferenceExpr{[]string{"deleted", "foo"}})
+		})
+	})
+
+	Describe("temporary", func() {
+		It("parses as a temporary node", func() {
+			parsesAs("&temporary", TemporaryExpr{})
+		})
+
+		It("parses the expression of the temporary node", func() {
+			parsesAs(
+				`&temporary merge || "default"`,
+				TemporaryExpr{
+					OrExpr{
+						MergeExpr{Path: []string{"foo"}},
+						StringExpr{"default"},
+					},
+				},
+				"foo",
+			)
+		})
+
+		It("is only allowed at the beginning", func() {
+			_, err := Parse(`"foo" || &temporary`, nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Describe("references", func() {
 		It("parses as a reference node", func() {
 			parsesAs("foo.bar-baz.fizz_buzz", ReferenceExpr{[]string{"foo", "bar-baz", "fizz_buzz"}})
@@ -66,11 +166,11 @@ var _ = Describe("parsing", func() {
 			parsesAs(
 				`"foo" bar merge`,
 				ConcatenationExpr{
-					StringExpr{"foo"},
 					ConcatenationExpr{
+						StringExpr{"foo"},
 						ReferenceExpr{[]string{"bar"}},
-						MergeExpr{},
 					},
+					MergeExpr{},
 				},
 			)
 		})
@@ -108,15 +208,28 @@ var _ = Describe("parsing", func() {
 					ReferenceExpr{[]string{"bar"}},
 				},
 			)
+		})
 
+		It("associates to the left", func() {
 			parsesAs(
 				`"foo" + bar + merge`,
 				AdditionExpr{
-					StringExpr{"foo"},
 					AdditionExpr{
+						StringExpr{"foo"},
 						ReferenceExpr{[]string{"bar"}},
-						MergeExpr{},
 					},
+					MergeExpr{},
+				},
+			)
+
+			parsesAs(
+				`"foo" + bar - merge`,
+				SubtractionExpr{
+					AdditionExpr{
+						StringExpr{"foo"},
+						ReferenceExpr{[]string{"bar"}},
+					},
+					MergeExpr{},
 				},
 			)
 		})
@@ -131,15 +244,208 @@ var _ = Describe("parsing", func() {
 					ReferenceExpr{[]string{"bar"}},
 				},
 			)
+		})
 
+		It("associates to the left", func() {
 			parsesAs(
 				`"foo" - bar - merge`,
 				SubtractionExpr{
-					StringExpr{"foo"},
 					SubtractionExpr{
+						StringExpr{"foo"},
 						ReferenceExpr{[]string{"bar"}},
-						MergeExpr{},
 					},
+					MergeExpr{},
+				},
+			)
+		})
+	})
+
+	Describe("multiplication", func() {
+		It("parses nodes separated by *", func() {
+			parsesAs(
+				`2 * bar`,
+				MultiplicationExpr{
+					IntegerExpr{2},
+					ReferenceExpr{[]string{"bar"}},
+				},
+			)
+		})
+
+		It("binds tighter than addition and subtraction", func() {
+			parsesAs(
+				`1 + 2 * bar - 3`,
+				SubtractionExpr{
+					AdditionExpr{
+						IntegerExpr{1},
+						MultiplicationExpr{
+							IntegerExpr{2},
+							ReferenceExpr{[]string{"bar"}},
+						},
+					},
+					IntegerExpr{3},
+				},
+			)
+		})
+	})
+
+	Describe("division", func() {
+		It("parses nodes separated by /", func() {
+			parsesAs(
+				`foo / 2`,
+				DivisionExpr{
+					ReferenceExpr{[]string{"foo"}},
+					IntegerExpr{2},
+				},
+			)
+		})
+
+		It("associates to the left", func() {
+			parsesAs(
+				`8 / 4 / 2`,
+				DivisionExpr{
+					DivisionExpr{
+						IntegerExpr{8},
+						IntegerExpr{4},
+					},
+					IntegerExpr{2},
+				},
+			)
+		})
+	})
+
+	Describe("modulo", func() {
+		It("parses nodes separated by %", func() {
+			parsesAs(
+				`foo % 2 * 3`,
+				MultiplicationExpr{
+					ModuloExpr{
+						ReferenceExpr{[]string{"foo"}},
+						IntegerExpr{2},
+					},
+					IntegerExpr{3},
+				},
+			)
+		})
+	})
+
+	Describe("comparisons", func() {
+		It("parses equality and inequality", func() {
+			parsesAs(
+				`foo == "bar"`,
+				EqualExpr{
+					ReferenceExpr{[]string{"foo"}},
+					StringExpr{"bar"},
+				},
+			)
+
+			parsesAs(
+				`foo != 1`,
+				NotEqualExpr{
+					ReferenceExpr{[]string{"foo"}},
+					IntegerExpr{1},
+				},
+			)
+		})
+
+		It("parses orderings", func() {
+			for _, operator := range []string{"<", "<=", ">", ">="} {
+				parsesAs(
+					"foo "+operator+" 1",
+					ComparisonExpr{
+						operator,
+						ReferenceExpr{[]string{"foo"}},
+						IntegerExpr{1},
+					},
+				)
+			}
+		})
+
+		It("binds looser than arithmetic", func() {
+			parsesAs(
+				`foo + 1 < bar * 2`,
+				ComparisonExpr{
+					"<",
+					AdditionExpr{
+						ReferenceExpr{[]string{"foo"}},
+						IntegerExpr{1},
+					},
+					MultiplicationExpr{
+						ReferenceExpr{[]string{"bar"}},
+						IntegerExpr{2},
+					},
+				},
+			)
+		})
+	})
+
+	Describe("boolean operators", func() {
+		It("parses && binding tighter than ||", func() {
+			parsesAs(
+				`foo && bar || baz`,
+				OrExpr{
+					AndExpr{
+						ReferenceExpr{[]string{"foo"}},
+						ReferenceExpr{[]string{"bar"}},
+					},
+					ReferenceExpr{[]string{"baz"}},
+				},
+			)
+		})
+
+		It("parses negations", func() {
+			parsesAs(
+				`foo && !bar == baz`,
+				AndExpr{
+					ReferenceExpr{[]string{"foo"}},
+					EqualExpr{
+						NotExpr{ReferenceExpr{[]string{"bar"}}},
+						ReferenceExpr{[]string{"baz"}},
+					},
+				},
+			)
+		})
+	})
+
+	Describe("conditionals", func() {
+		It("parses a condition with both branches", func() {
+			parsesAs(
+				`foo == 1 ? "one" : bar || "other"`,
+				ConditionalExpr{
+					EqualExpr{
+						ReferenceExpr{[]string{"foo"}},
+						IntegerExpr{1},
+					},
+					StringExpr{"one"},
+					OrExpr{
+						ReferenceExpr{[]string{"bar"}},
+						StringExpr{"other"},
+					},
+				},
+			)
+		})
+
+		It("does not require whitespace around the colon", func() {
+			parsesAs(
+				`foo ? 1 :2`,
+				ConditionalExpr{
+					ReferenceExpr{[]string{"foo"}},
+					IntegerExpr{1},
+					IntegerExpr{2},
+				},
+			)
+		})
+
+		It("parses nested conditionals", func() {
+			parsesAs(
+				`a ? b ? 1 : 2 : 3`,
+				ConditionalExpr{
+					ReferenceExpr{[]string{"a"}},
+					ConditionalExpr{
+						ReferenceExpr{[]string{"b"}},
+						IntegerExpr{1},
+						IntegerExpr{2},
+					},
+					IntegerExpr{3},
 				},
 			)
 		})
@@ -162,6 +468,64 @@ var _ = Describe("parsing", func() {
 				},
 			)
 		})
+
+		It("parses nested lists", func() {
+			parsesAs(
+				`[1, [2, 3], 4]`,
+				ListExpr{
+					[]Expression{
+						IntegerExpr{1},
+						ListExpr{
+							[]Expression{
+								IntegerExpr{2},
+								IntegerExpr{3},
+							},
+						},
+						IntegerExpr{4},
+					},
+				},
+			)
+		})
+	})
+
+	Describe("maps", func() {
+		It("parses an empty map", func() {
+			parsesAs(`{}`, MapExpr{})
+		})
+
+		It("parses keys and values separated by commas", func() {
+			parsesAs(
+				`{ "port": 8080, tls:false, "the name": name }`,
+				MapExpr{
+					map[string]Expression{
+						"port":     IntegerExpr{8080},
+						"tls":      BooleanExpr{false},
+						"the name": ReferenceExpr{[]string{"name"}},
+					},
+				},
+			)
+		})
+
+		It("parses nested maps and lists", func() {
+			parsesAs(
+				`{ "a": { "b": [1, {}] }, "c": 2 }`,
+				MapExpr{
+					map[string]Expression{
+						"a": MapExpr{
+							map[string]Expression{
+								"b": ListExpr{
+									[]Expression{
+										IntegerExpr{1},
+										MapExpr{},
+									},
+								},
+							},
+						},
+						"c": IntegerExpr{2},
+					},
+				},
+			)
+		})
 	})
 
 	Describe("calls", func() {
@@ -178,6 +542,104 @@ var _ = Describe("parsing", func() {
 				},
 			)
 		})
+
+		It("parses nested calls", func() {
+			parsesAs(
+				`foo(1, bar(2), [3])`,
+				CallExpr{
+					"foo",
+					[]Expression{
+						IntegerExpr{1},
+						CallExpr{
+							"bar",
+							[]Expression{IntegerExpr{2}},
+						},
+						ListExpr{[]Expression{IntegerExpr{3}}},
+					},
+				},
+			)
+		})
+	})
+
+	Describe("lambdas", func() {
+		It("parses lambdas passed to function calls", func() {
+			parsesAs(
+				`map(jobs, |job| -> job.name)`,
+				CallExpr{
+					"map",
+					[]Expression{
+						ReferenceExpr{[]string{"jobs"}},
+						LambdaExpr{
+							[]string{"job"},
+							ReferenceExpr{[]string{"job", "name"}},
+						},
+					},
+				},
+			)
+		})
+
+		It("parses lambdas with several parameters", func() {
+			parsesAs(
+				`foo(|a, b| -> a + b)`,
+				CallExpr{
+					"foo",
+					[]Expression{
+						LambdaExpr{
+							[]string{"a", "b"},
+							AdditionExpr{
+								ReferenceExpr{[]string{"a"}},
+								ReferenceExpr{[]string{"b"}},
+							},
+						},
+					},
+				},
+			)
+		})
+
+		It("parses a list and a lambda in brackets as a call", func() {
+			parsesAs(
+				`filter[jobs|x|->x.instances > 1]`,
+				CallExpr{
+					"filter",
+					[]Expression{
+						ReferenceExpr{[]string{"jobs"}},
+						LambdaExpr{
+							[]string{"x"},
+							ComparisonExpr{
+								">",
+								ReferenceExpr{[]string{"x", "instances"}},
+								IntegerExpr{1},
+							},
+						},
+					},
+				},
+			)
+		})
+
+		It("parses nested calls in brackets", func() {
+			parsesAs(
+				`map[sort[jobs |x| -> x.name] |x| -> x.name]`,
+				CallExpr{
+					"map",
+					[]Expression{
+						CallExpr{
+							"sort",
+							[]Expression{
+								ReferenceExpr{[]string{"jobs"}},
+								LambdaExpr{
+									[]string{"x"},
+									ReferenceExpr{[]string{"x", "name"}},
+								},
+							},
+						},
+						LambdaExpr{
+							[]string{"x"},
+							ReferenceExpr{[]string{"x", "name"}},
+						},
+					},
+				},
+			)
+		})
 	})
 
 	Describe("grouping", func() {
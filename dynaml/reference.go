@@ -10,10 +10,11 @@ type ReferenceExpr struct {
 	Path []string
 }
 
-func (e ReferenceExpr) Evaluate(binding Binding) (yaml.Node, bool) {
+func (e ReferenceExpr) Evaluate(binding Binding) (yaml.Node, EvaluationInfo, bool) {
 	var step yaml.Node
 	var ok bool
 
+	info := DefaultInfo()
 	fromRoot := e.Path[0] == ""
 
 	for i := 0; i < len(e.Path); i++ {
@@ -24,16 +25,17 @@ func (e ReferenceExpr) Evaluate(binding Binding) (yaml.Node, bool) {
 		}
 
 		if !ok {
-			return nil, false
+			return info.Error("%s not found", strings.Join(e.Path[:i+1], "."))
 		}
 
 		switch step.Value().(type) {
 		case Expression:
-			return node(e), true
+			info.Issue = strings.Join(e.Path[:i+1], ".") + " is not resolved yet"
+			return node(e, binding), info, true
 		}
 	}
 
-	return step, true
+	return step, info, true
 }
 
 func (e ReferenceExpr) String() string {
@@ -14,8 +14,8 @@ var _ = Describe("references", func() {
 
 			binding := FakeBinding{
 				FoundReferences: map[string]yaml.Node{
-					"foo":     node(nil),
-					"foo.bar": node(42),
+					"foo":     node(nil, nil),
+					"foo.bar": node(42, nil),
 				},
 			}
 
@@ -28,7 +28,7 @@ var _ = Describe("references", func() {
 
 				binding := FakeBinding{
 					FoundReferences: map[string]yaml.Node{
-						"foo": node(MergeExpr{}),
+						"foo": node(MergeExpr{}, nil),
 					},
 				}
 
@@ -45,5 +45,18 @@ var _ = Describe("references", func() {
 
 			Expect(expr).To(FailToEvaluate(binding))
 		})
+
+		It("reports the first step that is missing", func() {
+			expr := ReferenceExpr{[]string{"foo", "bar", "baz"}}
+
+			binding := FakeBinding{
+				FoundReferences: map[string]yaml.Node{
+					"foo": node(nil, nil),
+				},
+			}
+
+			_, info, _ := expr.Evaluate(binding)
+			Expect(info.Issue).To(Equal("foo.bar not found"))
+		})
 	})
 })
@@ -0,0 +1,69 @@
+package dynaml
+
+import (
+	"reflect"
+)
+
+// References returns the paths of all references made by an expression,
+// including those of its nested expressions.
+//
+// Expressions are walked by reflection, so new expression types are covered
+// as long as they keep their operands in (slices or maps of) fields.
+func References(expr Expression) [][]string {
+	refs := [][]string{}
+	collectReferences(reflect.ValueOf(expr), &refs)
+	return refs
+}
+
+func collectReferences(val reflect.Value, refs *[][]string) {
+	switch val.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if !val.IsNil() {
+			collectReferences(val.Elem(), refs)
+		}
+
+	case reflect.Struct:
+		if val.CanInterface() {
+			switch expr := val.Interface().(type) {
+			case ReferenceExpr:
+				*refs = append(*refs, expr.Path)
+				return
+			case LambdaExpr:
+				collectLambdaReferences(expr, refs)
+				return
+			}
+		}
+
+		for i := 0; i < val.NumField(); i++ {
+			collectReferences(val.Field(i), refs)
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			collectReferences(val.Index(i), refs)
+		}
+
+	case reflect.Map:
+		for _, key := range val.MapKeys() {
+			collectReferences(val.MapIndex(key), refs)
+		}
+	}
+}
+
+// collectLambdaReferences skips references to the parameters of a lambda, as
+// they are bound when the lambda is called.
+func collectLambdaReferences(lambda LambdaExpr, refs *[][]string) {
+	for _, ref := range References(lambda.Body) {
+		parameter := false
+		for _, name := range lambda.Parameters {
+			if ref[0] == name {
+				parameter = true
+				break
+			}
+		}
+
+		if !parameter {
+			*refs = append(*refs, ref)
+		}
+	}
+}
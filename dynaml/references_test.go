@@ -0,0 +1,53 @@
+package dynaml
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("references of an expression", func() {
+	It("collects the references of nested expressions", func() {
+		expr := OrExpr{
+			AdditionExpr{
+				ReferenceExpr{[]string{"foo", "bar"}},
+				IntegerExpr{1},
+			},
+			ListExpr{
+				[]Expression{
+					StringExpr{"fizz"},
+					ReferenceExpr{[]string{"", "buzz"}},
+				},
+			},
+		}
+
+		Expect(References(expr)).To(Equal([][]string{
+			{"foo", "bar"},
+			{"", "buzz"},
+		}))
+	})
+
+	It("skips references to the parameters of lambdas", func() {
+		expr := CallExpr{
+			"map",
+			[]Expression{
+				ReferenceExpr{[]string{"jobs"}},
+				LambdaExpr{
+					[]string{"job"},
+					ConcatenationExpr{
+						ReferenceExpr{[]string{"prefix"}},
+						ReferenceExpr{[]string{"job", "name"}},
+					},
+				},
+			},
+		}
+
+		Expect(References(expr)).To(Equal([][]string{
+			{"jobs"},
+			{"prefix"},
+		}))
+	})
+
+	It("returns an empty list for expressions without references", func() {
+		Expect(References(MergeExpr{Path: []string{"foo"}})).To(BeEmpty())
+	})
+})
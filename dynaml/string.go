@@ -10,8 +10,8 @@ type StringExpr struct {
 	Value string
 }
 
-func (e StringExpr) Evaluate(Binding) (yaml.Node, bool) {
-	return node(e.Value), true
+func (e StringExpr) Evaluate(binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	return node(e.Value, binding), DefaultInfo(), true
 }
 
 func (e StringExpr) String() string {
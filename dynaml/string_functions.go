@@ -0,0 +1,205 @@
+package dynaml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+func init() {
+	RegisterFunction(Function{
+		Name:           "join",
+		Arguments:      []ArgumentType{StringArgument, ListArgument},
+		Implementation: funcJoin,
+	})
+
+	RegisterFunction(Function{
+		Name:           "split",
+		Arguments:      []ArgumentType{StringArgument, StringArgument},
+		Implementation: funcSplit,
+	})
+
+	RegisterFunction(Function{
+		Name:           "trim",
+		Arguments:      []ArgumentType{StringArgument, StringArgument},
+		Optional:       1,
+		Implementation: funcTrim,
+	})
+
+	RegisterFunction(Function{
+		Name:           "upper",
+		Arguments:      []ArgumentType{StringArgument},
+		Implementation: mapString(strings.ToUpper),
+	})
+
+	RegisterFunction(Function{
+		Name:           "lower",
+		Arguments:      []ArgumentType{StringArgument},
+		Implementation: mapString(strings.ToLower),
+	})
+
+	RegisterFunction(Function{
+		Name:           "replace",
+		Arguments:      []ArgumentType{StringArgument, StringArgument, StringArgument},
+		Implementation: funcReplace,
+	})
+
+	RegisterFunction(Function{
+		Name:           "format",
+		Arguments:      []ArgumentType{StringArgument, AnyArgument},
+		Optional:       1,
+		Variadic:       true,
+		Implementation: funcFormat,
+	})
+
+	RegisterFunction(Function{
+		Name:           "substr",
+		Arguments:      []ArgumentType{StringArgument, IntArgument, IntArgument},
+		Optional:       1,
+		Implementation: funcSubstr,
+	})
+
+	RegisterFunction(Function{
+		Name:           "length",
+		Arguments:      []ArgumentType{AnyArgument},
+		Implementation: funcLength,
+	})
+}
+
+// join(separator, list) joins the strings and numbers of a list.
+func funcJoin(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	list := args[1].Value().([]yaml.Node)
+
+	elements := make([]string, len(list))
+	for i, elem := range list {
+		str, ok := scalarString(elem)
+		if !ok {
+			return info.Error("join: list entry %d must be a string or a number, but is %s", i, typeName(elem))
+		}
+
+		elements[i] = str
+	}
+
+	return node(strings.Join(elements, args[0].Value().(string)), binding), info, true
+}
+
+// split(separator, string) splits a string into a list of strings.
+func funcSplit(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	parts := strings.Split(args[1].Value().(string), args[0].Value().(string))
+
+	list := make([]yaml.Node, len(parts))
+	for i, part := range parts {
+		list[i] = node(part, binding)
+	}
+
+	return node(list, binding), DefaultInfo(), true
+}
+
+// trim(string) strips leading and trailing whitespace; trim(string, chars)
+// strips the given characters instead.
+func funcTrim(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	str := args[0].Value().(string)
+
+	if len(args) == 1 {
+		return node(strings.TrimSpace(str), binding), DefaultInfo(), true
+	}
+
+	return node(strings.Trim(str, args[1].Value().(string)), binding), DefaultInfo(), true
+}
+
+func mapString(mapping func(string) string) func([]yaml.Node, Binding) (yaml.Node, EvaluationInfo, bool) {
+	return func(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+		return node(mapping(args[0].Value().(string)), binding), DefaultInfo(), true
+	}
+}
+
+// replace(string, old, new) replaces all occurrences of old.
+func funcReplace(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	str := args[0].Value().(string)
+	old := args[1].Value().(string)
+	new := args[2].Value().(string)
+
+	return node(strings.Replace(str, old, new, -1), binding), DefaultInfo(), true
+}
+
+// format(format, args...) formats its arguments printf-style.
+func funcFormat(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	values := make([]interface{}, len(args)-1)
+	for i, arg := range args[1:] {
+		switch arg.Value().(type) {
+		case string, int64, float64, bool, nil:
+			values[i] = arg.Value()
+		default:
+			return info.Error("format: argument %d must be a string, number, bool or nil, but is %s", i+2, typeName(arg))
+		}
+	}
+
+	return node(fmt.Sprintf(args[0].Value().(string), values...), binding), info, true
+}
+
+// substr(string, start) and substr(string, start, end) return the
+// characters from start up to (but excluding) end. Negative positions count
+// from the end of the string.
+func funcSubstr(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	runes := []rune(args[0].Value().(string))
+
+	positions := []int{0, len(runes)}
+	for i, arg := range args[1:] {
+		position := arg.Value().(int64)
+		if position < 0 {
+			position += int64(len(runes))
+		}
+
+		if position < 0 || position > int64(len(runes)) {
+			return info.Error("substr: position %d out of range for a string of length %d", arg.Value(), len(runes))
+		}
+
+		positions[i] = int(position)
+	}
+
+	start, end := positions[0], positions[1]
+	if start > end {
+		return info.Error("substr: start %d is after end %d", start, end)
+	}
+
+	return node(string(runes[start:end]), binding), info, true
+}
+
+// length(x) returns the number of characters of a string or the number of
+// entries of a list or map.
+func funcLength(args []yaml.Node, binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	switch val := args[0].Value().(type) {
+	case string:
+		return node(utf8.RuneCountInString(val), binding), info, true
+	case []yaml.Node:
+		return node(len(val), binding), info, true
+	case map[string]yaml.Node:
+		return node(len(val), binding), info, true
+	}
+
+	return info.Error("length: argument must be a string, list or map, but is %s", typeName(args[0]))
+}
+
+func scalarString(n yaml.Node) (string, bool) {
+	switch val := n.Value().(type) {
+	case string:
+		return val, true
+	case int64:
+		return strconv.FormatInt(val, 10), true
+	case float64:
+		return formatFloat(val), true
+	}
+
+	return "", false
+}
@@ -0,0 +1,135 @@
+package dynaml
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+func call(name string, args ...Expression) CallExpr {
+	return CallExpr{Name: name, Arguments: args}
+}
+
+var _ = Describe("string functions", func() {
+	Describe("join(separator, list)", func() {
+		It("joins strings and numbers", func() {
+			expr := call("join",
+				StringExpr{", "},
+				ListExpr{[]Expression{StringExpr{"a"}, IntegerExpr{1}, FloatExpr{1.5}}},
+			)
+
+			Expect(expr).To(EvaluateAs("a, 1, 1.5", FakeBinding{}))
+		})
+
+		It("fails if the list contains non-scalars", func() {
+			expr := call("join",
+				StringExpr{","},
+				ListExpr{[]Expression{ListExpr{}}},
+			)
+
+			Expect(expr).To(FailToEvaluate(FakeBinding{}))
+		})
+
+		It("fails if the second argument is not a list", func() {
+			expr := call("join", StringExpr{","}, StringExpr{"a"})
+
+			_, info, ok := expr.Evaluate(FakeBinding{})
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("join: argument 2 must be a list, but is string"))
+		})
+	})
+
+	Describe("split(separator, string)", func() {
+		It("splits the string", func() {
+			expr := call("split", StringExpr{","}, StringExpr{"a,b,c"})
+
+			Expect(expr).To(EvaluateAs([]yaml.Node{node("a", nil), node("b", nil), node("c", nil)}, FakeBinding{}))
+		})
+	})
+
+	Describe("trim(string)", func() {
+		It("strips whitespace", func() {
+			Expect(call("trim", StringExpr{"  a b \n"})).To(EvaluateAs("a b", FakeBinding{}))
+		})
+
+		It("strips the given characters", func() {
+			Expect(call("trim", StringExpr{"--a-b--"}, StringExpr{"-"})).To(EvaluateAs("a-b", FakeBinding{}))
+		})
+	})
+
+	Describe("upper(string) and lower(string)", func() {
+		It("changes the case", func() {
+			Expect(call("upper", StringExpr{"aBc"})).To(EvaluateAs("ABC", FakeBinding{}))
+			Expect(call("lower", StringExpr{"aBc"})).To(EvaluateAs("abc", FakeBinding{}))
+		})
+
+		It("fails on the wrong number of arguments", func() {
+			_, info, ok := call("upper").Evaluate(FakeBinding{})
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("upper: expected 1 argument, got 0"))
+		})
+	})
+
+	Describe("replace(string, old, new)", func() {
+		It("replaces all occurrences", func() {
+			expr := call("replace", StringExpr{"a.b.c"}, StringExpr{"."}, StringExpr{"-"})
+
+			Expect(expr).To(EvaluateAs("a-b-c", FakeBinding{}))
+		})
+	})
+
+	Describe("format(format, args...)", func() {
+		It("formats the arguments", func() {
+			expr := call("format", StringExpr{"%s:%d"}, StringExpr{"host"}, IntegerExpr{80})
+
+			Expect(expr).To(EvaluateAs("host:80", FakeBinding{}))
+		})
+
+		It("fails on non-scalar arguments", func() {
+			expr := call("format", StringExpr{"%v"}, ListExpr{})
+
+			Expect(expr).To(FailToEvaluate(FakeBinding{}))
+		})
+	})
+
+	Describe("substr(string, start, end)", func() {
+		It("returns the characters between start and end", func() {
+			Expect(call("substr", StringExpr{"héllo"}, IntegerExpr{1}, IntegerExpr{3})).To(EvaluateAs("él", FakeBinding{}))
+		})
+
+		It("defaults end to the end of the string", func() {
+			Expect(call("substr", StringExpr{"hello"}, IntegerExpr{2})).To(EvaluateAs("llo", FakeBinding{}))
+		})
+
+		It("counts negative positions from the end", func() {
+			Expect(call("substr", StringExpr{"hello"}, IntegerExpr{-3}, IntegerExpr{-1})).To(EvaluateAs("ll", FakeBinding{}))
+		})
+
+		It("fails on positions out of range", func() {
+			_, info, ok := call("substr", StringExpr{"hello"}, IntegerExpr{6}).Evaluate(FakeBinding{})
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue).To(Equal("substr: position 6 out of range for a string of length 5"))
+		})
+	})
+
+	Describe("length(x)", func() {
+		It("counts the characters of a string", func() {
+			Expect(call("length", StringExpr{"héllo"})).To(EvaluateAs(5, FakeBinding{}))
+		})
+
+		It("counts the entries of a list", func() {
+			Expect(call("length", ListExpr{[]Expression{IntegerExpr{1}, IntegerExpr{2}}})).To(EvaluateAs(2, FakeBinding{}))
+		})
+
+		It("counts the entries of a map", func() {
+			expr := call("length", MapExpr{map[string]Expression{"a": IntegerExpr{1}}})
+
+			Expect(expr).To(EvaluateAs(1, FakeBinding{}))
+		})
+
+		It("fails on numbers", func() {
+			Expect(call("length", IntegerExpr{1})).To(FailToEvaluate(FakeBinding{}))
+		})
+	})
+})
@@ -15,6 +15,13 @@ var _ = Describe("subtraction", func() {
 		Expect(expr).To(EvaluateAs(4, FakeBinding{}))
 	})
 
+	It("subtracts from left to right when chained", func() {
+		expr, err := Parse("10 - 3 - 2", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(expr).To(EvaluateAs(5, FakeBinding{}))
+	})
+
 	Context("when the left-hand side is not an integer", func() {
 		It("fails", func() {
 			expr := SubtractionExpr{
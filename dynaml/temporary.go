@@ -0,0 +1,39 @@
+package dynaml
+
+import (
+	"fmt"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+// TemporaryExpr marks a node as local to the template: it can be referred
+// to, but is removed from the output. Without an expression it evaluates to
+// nil, as for "<<: (( &temporary ))" marking a whole map or list.
+type TemporaryExpr struct {
+	Expression Expression
+}
+
+func (e TemporaryExpr) Evaluate(binding Binding) (yaml.Node, EvaluationInfo, bool) {
+	if e.Expression == nil {
+		return node(nil, binding), DefaultInfo(), true
+	}
+
+	val, info, ok := e.Expression.Evaluate(binding)
+	if !ok {
+		return nil, info, false
+	}
+
+	if isExpression(val) {
+		return node(e, binding), info, true
+	}
+
+	return val, info, true
+}
+
+func (e TemporaryExpr) String() string {
+	if e.Expression == nil {
+		return "&temporary"
+	}
+
+	return fmt.Sprintf("&temporary %s", e.Expression)
+}
@@ -0,0 +1,36 @@
+package dynaml
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+var _ = Describe("temporary", func() {
+	It("evaluates to its expression", func() {
+		Expect(TemporaryExpr{IntegerExpr{42}}).To(EvaluateAs(42, FakeBinding{}))
+	})
+
+	It("evaluates to nil without an expression", func() {
+		Expect(TemporaryExpr{}).To(EvaluateAs(nil, FakeBinding{}))
+	})
+
+	Context("when its expression refers to another expression", func() {
+		It("returns itself so the referred node can evaluate first", func() {
+			expr := TemporaryExpr{ReferenceExpr{[]string{"foo"}}}
+
+			binding := FakeBinding{
+				FoundReferences: map[string]yaml.Node{
+					"foo": node(MergeExpr{}, nil),
+				},
+			}
+
+			Expect(expr).To(EvaluateAs(expr, binding))
+		})
+	})
+
+	It("fails if its expression fails", func() {
+		Expect(TemporaryExpr{FailingExpr{}}).To(FailToEvaluate(FakeBinding{}))
+	})
+})
@@ -5,14 +5,60 @@ import (
 )
 
 func Cascade(template yaml.Node, templates ...yaml.Node) (yaml.Node, error) {
-	for i := len(templates) - 1; i >= 0; i-- {
-		flowed, err := Flow(templates[i], templates[i+1:]...)
+	return Environment{}.Cascade(template, templates...)
+}
+
+// Cascade flows the templates into each other like the function Cascade,
+// making the environment's variables available to all of them.
+func (e Environment) Cascade(template yaml.Node, templates ...yaml.Node) (yaml.Node, error) {
+	e = e.withExecCache()
+
+	stubs, err := e.flowStubs(templates)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.Flow(template, stubs...)
+}
+
+// CascadeEach cascades each of the templates, e.g. the documents of a stream,
+// with the same stubs. The stubs are flowed only once for all of them.
+func (e Environment) CascadeEach(templates []yaml.Node, stubs ...yaml.Node) ([]yaml.Node, error) {
+	e = e.withExecCache()
+
+	stubs, err := e.flowStubs(stubs)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []yaml.Node{}
+
+	for _, template := range templates {
+		flowed, err := e.Flow(template, stubs...)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, flowed)
+	}
+
+	return results, nil
+}
+
+// flowStubs flows each stub with the ones following it, leaving the given
+// list alone.
+func (e Environment) flowStubs(stubs []yaml.Node) ([]yaml.Node, error) {
+	flowed := make([]yaml.Node, len(stubs))
+	copy(flowed, stubs)
+
+	for i := len(flowed) - 1; i >= 0; i-- {
+		stub, err := e.flowStub(flowed[i], flowed[i+1:]...)
 		if err != nil {
 			return nil, err
 		}
 
-		templates[i] = flowed
+		flowed[i] = stub
 	}
 
-	return Flow(template, templates...)
+	return flowed, nil
 }
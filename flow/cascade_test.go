@@ -1,8 +1,13 @@
 package flow
 
 import (
+	"io/ioutil"
+	"os"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
 )
 
 var _ = Describe("Cascading YAML templates", func() {
@@ -78,4 +83,218 @@ baz:
 			Expect(source).To(CascadeAs(resolved, secondary, tertiary, stub))
 		})
 	})
+
+	Context("when a stub deletes nodes", func() {
+		It("removes them from the template", func() {
+			source := parseYAML(`
+---
+jobs:
+- name: api
+  instances: 2
+- name: worker
+  instances: 1
+properties:
+  debug: true
+  port: 8080
+`)
+
+			stub := parseYAML(`
+---
+jobs:
+- name: worker
+  <<: (( delete ))
+properties:
+  debug: (( delete ))
+`)
+
+			resolved := parseYAML(`
+---
+jobs:
+- name: api
+  instances: 2
+properties:
+  port: 8080
+`)
+
+			Expect(source).To(CascadeAs(resolved, stub))
+		})
+
+		It("passes the deletions of later stubs on", func() {
+			source := parseYAML(`
+---
+properties:
+  debug: true
+  port: 8080
+`)
+
+			secondary := parseYAML(`
+---
+properties:
+  port: 9090
+`)
+
+			stub := parseYAML(`
+---
+properties:
+  debug: (( delete ))
+`)
+
+			resolved := parseYAML(`
+---
+properties:
+  port: 9090
+`)
+
+			Expect(source).To(CascadeAs(resolved, secondary, stub))
+		})
+	})
+
+	Context("with several templates for the same stubs", func() {
+		It("cascades each of them", func() {
+			first := parseYAML(`
+---
+name: (( merge ))
+`)
+
+			second := parseYAML(`
+---
+uuid: (( merge ))
+`)
+
+			stub := parseYAML(`
+---
+name: (( "deployment-" uuid ))
+uuid: some-uuid
+`)
+
+			flowed, err := Environment{}.CascadeEach([]yaml.Node{first, second}, stub)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(flowed).To(HaveLen(2))
+			Expect(flowed[0].EquivalentToNode(parseYAML(`name: deployment-some-uuid`))).To(BeTrue())
+			Expect(flowed[1].EquivalentToNode(parseYAML(`uuid: some-uuid`))).To(BeTrue())
+		})
+
+		It("leaves the given stubs alone", func() {
+			template := parseYAML(`
+---
+name: (( merge ))
+`)
+
+			stubs := []yaml.Node{
+				parseYAML(`name: (( merge ))`),
+				parseYAML(`name: deployment`),
+			}
+
+			_, err := Environment{}.CascadeEach([]yaml.Node{template}, stubs...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stubs[0].EquivalentToNode(parseYAML(`name: (( merge ))`))).To(BeTrue())
+		})
+	})
+
+	Context("with variables", func() {
+		It("makes them available to all templates", func() {
+			source := parseYAML(`
+---
+name: (( vars.deployment ))
+director_uuid: (( merge ))
+`)
+
+			stub := parseYAML(`
+---
+director_uuid: (( vars.director ))
+`)
+
+			environment := Environment{
+				Variables: map[string]yaml.Node{
+					"deployment": yaml.NewNode("my-deployment", "variables"),
+					"director":   yaml.NewNode("some-uuid", "variables"),
+				},
+			}
+
+			resolved := parseYAML(`
+---
+name: my-deployment
+director_uuid: some-uuid
+`)
+
+			flowed, err := environment.Cascade(source, stub)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(flowed.EquivalentToNode(resolved)).To(BeTrue())
+		})
+	})
+
+	Context("when running commands is enabled", func() {
+		var counter string
+
+		BeforeEach(func() {
+			file, err := ioutil.TempFile("", "spiff-exec")
+			Expect(err).NotTo(HaveOccurred())
+			file.Close()
+
+			counter = file.Name()
+		})
+
+		AfterEach(func() {
+			os.Remove(counter)
+		})
+
+		It("runs each command once for all templates", func() {
+			command := `exec("sh", "-c", "echo run >> ` + counter + `; echo some-uuid")`
+
+			source := parseYAML(`
+---
+director_uuid: (( merge ))
+name: (( "deployment-" ` + command + ` ))
+`)
+
+			stub := parseYAML(`
+---
+director_uuid: (( ` + command + ` ))
+`)
+
+			resolved := parseYAML(`
+---
+director_uuid: some-uuid
+name: deployment-some-uuid
+`)
+
+			flowed, err := Environment{EnableExec: true}.Cascade(source, stub)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(flowed.EquivalentToNode(resolved)).To(BeTrue())
+
+			runs, err := ioutil.ReadFile(counter)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(runs)).To(Equal("run\n"))
+		})
+
+		It("takes the output of commands as data rather than dynaml", func() {
+			source := parseYAML(`
+---
+output: '(( exec("printf", "a: (( 1 + 1 ))\nb:\n- (( a ))\n") ))'
+`)
+
+			flowed, err := Environment{EnableExec: true}.Cascade(source)
+			Expect(err).NotTo(HaveOccurred())
+
+			output, found := yaml.Find(flowed, "output")
+			Expect(found).To(BeTrue())
+
+			Expect(output.EquivalentToNode(parseYAML(`
+---
+a: (( 1 + 1 ))
+b:
+- (( a ))
+`))).To(BeTrue())
+		})
+
+		It("is disabled by default", func() {
+			source := parseYAML(`
+---
+name: (( exec("echo", "foo") ))
+`)
+
+			_, err := Cascade(source)
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
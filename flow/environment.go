@@ -1,19 +1,65 @@
 package flow
 
 import (
+	"github.com/cloudfoundry-incubator/spiff/dynaml"
 	"github.com/cloudfoundry-incubator/spiff/yaml"
 )
 
 type Scope []map[string]yaml.Node
 
+// VariablesName is the name references use for the variables given to spiff
+// from the outside, as in (( vars.deployment_name )) or (( .vars.name )). If
+// variables are given, it refers to them even where the template defines a
+// node of that name.
+const VariablesName = "vars"
+
 type Environment struct {
 	Scope Scope
 	Path  []string
 
 	Stubs []yaml.Node
+
+	Variables map[string]yaml.Node
+
+	// EnableExec allows the templates to run commands with exec().
+	EnableExec bool
+
+	origin yaml.Origin
+
+	// keyNames holds the key name of the lists stepped through along Path,
+	// so that the stubs are searched by the same keys as the template.
+	keyNames []string
+
+	// noMerge keeps the stubs from overriding the nodes below a map or list
+	// marked with <<: (( merge none )).
+	noMerge bool
+
+	// scopePaths holds the path of each map in Scope, so that lookups can
+	// be reported to the recorder by their absolute path.
+	scopePaths [][]string
+	recorder   *lookupRecorder
+
+	// execCache is shared by all templates flowed in one merge.
+	execCache *dynaml.ExecCache
+}
+
+func (e Environment) Origin() yaml.Origin {
+	return e.origin
+}
+
+func (e Environment) ExecCache() *dynaml.ExecCache {
+	return e.execCache
 }
 
 func (e Environment) FindFromRoot(path []string) (yaml.Node, bool) {
+	if val, found, ok := e.findVariable(path); ok {
+		return val, found
+	}
+
+	if e.recorder != nil {
+		e.recorder.record(path)
+	}
+
 	if len(e.Scope) == 0 {
 		return nil, false
 	}
@@ -22,6 +68,14 @@ func (e Environment) FindFromRoot(path []string) (yaml.Node, bool) {
 }
 
 func (e Environment) FindReference(path []string) (yaml.Node, bool) {
+	if val, found, ok := e.findVariable(path); ok {
+		return val, found
+	}
+
+	if e.recorder != nil {
+		e.recordReference(path)
+	}
+
 	root, found := resolveSymbol(path[0], e.Scope)
 	if !found {
 		return nil, false
@@ -30,9 +84,24 @@ func (e Environment) FindReference(path []string) (yaml.Node, bool) {
 	return yaml.Find(root, path[1:]...)
 }
 
+// findVariable looks the path up among the variables, if there are any and
+// the path starts with VariablesName. The last value tells whether it did.
+// Variables never change, so their lookups are not recorded.
+func (e Environment) findVariable(path []string) (yaml.Node, bool, bool) {
+	if e.Variables == nil || len(path) == 0 || path[0] != VariablesName {
+		return nil, false, false
+	}
+
+	val, found := yaml.Find(yaml.NewNode(e.Variables, "variables"), path[1:]...)
+
+	return val, found, true
+}
+
 func (e Environment) FindInStubs(path []string) (yaml.Node, bool) {
+	keyNames := e.keyNamesAlong(path)
+
 	for _, stub := range e.Stubs {
-		val, found := yaml.Find(stub, path...)
+		val, found := yaml.FindWithKeys(stub, keyNames, path...)
 		if found {
 			return val, true
 		}
@@ -41,10 +110,26 @@ func (e Environment) FindInStubs(path []string) (yaml.Node, bool) {
 	return nil, false
 }
 
+// deletedInStubs tells whether the stubs delete the node at the path of the
+// environment, unless they are kept from overriding it.
+func (e Environment) deletedInStubs() bool {
+	if e.noMerge {
+		return false
+	}
+
+	val, found := e.FindInStubs(e.Path)
+	return found && deleted(val)
+}
+
 func (e Environment) WithScope(step map[string]yaml.Node) Environment {
 	newScope := make([]map[string]yaml.Node, len(e.Scope))
 	copy(newScope, e.Scope)
 	e.Scope = append(newScope, step)
+
+	newScopePaths := make([][]string, len(e.scopePaths))
+	copy(newScopePaths, e.scopePaths)
+	e.scopePaths = append(newScopePaths, e.Path)
+
 	return e
 }
 
@@ -55,6 +140,66 @@ func (e Environment) WithPath(step string) Environment {
 	return e
 }
 
+// withListPath steps into an entry of a list whose entries are identified by
+// the given key name.
+func (e Environment) withListPath(step string, keyName string) Environment {
+	keyNames := make([]string, len(e.Path)+1)
+	copy(keyNames, e.keyNames)
+	keyNames[len(e.Path)] = keyName
+
+	e = e.WithPath(step)
+	e.keyNames = keyNames
+
+	return e
+}
+
+// keyNamesAlong returns the key names of the lists along the part of the
+// path that the environment's path shares.
+func (e Environment) keyNamesAlong(path []string) []string {
+	keyNames := []string{}
+
+	for i, step := range path {
+		if i >= len(e.Path) || i >= len(e.keyNames) || e.Path[i] != step {
+			break
+		}
+
+		keyNames = append(keyNames, e.keyNames[i])
+	}
+
+	return keyNames
+}
+
+func (e Environment) WithOrigin(origin yaml.Origin) Environment {
+	e.origin = origin
+	return e
+}
+
+func (e Environment) withoutMerge() Environment {
+	e.noMerge = true
+	return e
+}
+
+func (e Environment) withRecorder(recorder *lookupRecorder) Environment {
+	e.recorder = recorder
+	return e
+}
+
+// recordReference records the lookup of a reference in every scope up to
+// the one defining its first step, as any of them may define it later on.
+func (e Environment) recordReference(path []string) {
+	for i := len(e.Scope); i > 0 && i <= len(e.scopePaths); i-- {
+		absolute := make([]string, 0, len(e.scopePaths[i-1])+len(path))
+		absolute = append(absolute, e.scopePaths[i-1]...)
+		absolute = append(absolute, path...)
+
+		e.recorder.record(absolute)
+
+		if e.Scope[i-1][path[0]] != nil {
+			return
+		}
+	}
+}
+
 func resolveSymbol(name string, context Scope) (yaml.Node, bool) {
 	for i := len(context); i > 0; i-- {
 		ctx := context[i-1]
@@ -66,3 +211,13 @@ func resolveSymbol(name string, context Scope) (yaml.Node, bool) {
 
 	return nil, false
 }
+
+// withExecCache starts the cache of the commands run in a merge, if running
+// commands is enabled.
+func (e Environment) withExecCache() Environment {
+	if e.EnableExec && e.execCache == nil {
+		e.execCache = dynaml.NewExecCache()
+	}
+
+	return e
+}
@@ -113,6 +113,78 @@ foo:
 				Expect(found).To(BeFalse())
 			})
 		})
+
+		Context("when referring to the variables", func() {
+			variables := map[string]yaml.Node{
+				"deployment": yaml.NewNode("my-deployment", "variables"),
+			}
+
+			It("finds the variable", func() {
+				environment := Environment{Variables: variables}
+
+				val, found := environment.FindReference([]string{"vars", "deployment"})
+				Expect(found).To(BeTrue())
+				Expect(val.Value()).To(Equal("my-deployment"))
+			})
+
+			It("is not shadowed by the template's own nodes", func() {
+				tree := parseYAML(`
+---
+vars:
+  deployment: other-deployment
+`)
+
+				environment := Environment{
+					Scope:     []map[string]yaml.Node{tree.Value().(map[string]yaml.Node)},
+					Variables: variables,
+				}
+
+				val, found := environment.FindReference([]string{"vars", "deployment"})
+				Expect(found).To(BeTrue())
+				Expect(val.Value()).To(Equal("my-deployment"))
+
+				_, found = environment.FindReference([]string{"vars", "other"})
+				Expect(found).To(BeFalse())
+			})
+
+			It("finds the variable from the root", func() {
+				tree := parseYAML(`
+---
+vars:
+  deployment: other-deployment
+`)
+
+				environment := Environment{
+					Scope:     []map[string]yaml.Node{tree.Value().(map[string]yaml.Node)},
+					Variables: variables,
+				}
+
+				val, found := environment.FindFromRoot([]string{"vars", "deployment"})
+				Expect(found).To(BeTrue())
+				Expect(val.Value()).To(Equal("my-deployment"))
+			})
+
+			It("leaves the template's own nodes alone without variables", func() {
+				tree := parseYAML(`
+---
+vars:
+  deployment: other-deployment
+`)
+
+				environment := Environment{
+					Scope: []map[string]yaml.Node{tree.Value().(map[string]yaml.Node)},
+				}
+
+				val, found := environment.FindReference([]string{"vars", "deployment"})
+				Expect(found).To(BeTrue())
+				Expect(val.Value()).To(Equal("other-deployment"))
+			})
+
+			It("returns false as the second value if there are none", func() {
+				_, found := Environment{}.FindReference([]string{"vars", "deployment"})
+				Expect(found).To(BeFalse())
+			})
+		})
 	})
 
 	Describe("finding a path from the root", func() {
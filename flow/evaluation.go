@@ -0,0 +1,360 @@
+package flow
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+// evaluate resolves the dynaml nodes of a flowed tree in the order of their
+// references. Each node is flowed once up front, and again only when a
+// node it looked up during its last flow has changed.
+func evaluate(root yaml.Node, env Environment) yaml.Node {
+	schedule := newSchedule(root)
+	copies := &copies{}
+
+	for {
+		item, ok := schedule.next()
+		if !ok {
+			break
+		}
+
+		recorder := &lookupRecorder{root: root}
+
+		here, hereEnv, override, found := locate(root, item.path, env.withRecorder(recorder))
+		if !found {
+			continue
+		}
+
+		flowed := flow(here, hereEnv, override)
+
+		schedule.watch(item, recorder.paths)
+
+		// the nodes looked up may have become part of the flowed node
+		for _, lookup := range recorder.paths {
+			copies.disown(lookup)
+		}
+
+		if reflect.DeepEqual(here, flowed) {
+			continue
+		}
+
+		root = replaceNode(root, item.path, flowed, copies)
+
+		schedule.changed(item.path)
+	}
+
+	return root
+}
+
+type scheduledItem struct {
+	path  []string
+	order int
+
+	// watched holds the path of the item and the paths it looked up during
+	// its last flow.
+	watched [][]string
+}
+
+type schedule struct {
+	queue  []*scheduledItem
+	queued map[*scheduledItem]bool
+
+	// exact indexes the items by the paths they watch, and within by every
+	// ancestor of these paths, so that a change only wakes the items
+	// watching the changed node, a node below it or a node containing it.
+	exact  map[string]map[*scheduledItem]bool
+	within map[string]map[*scheduledItem]bool
+}
+
+// newSchedule queues all dynaml nodes of a tree, dependencies first.
+func newSchedule(root yaml.Node) *schedule {
+	deps := dependencies{}
+	paths := map[string][]string{}
+	collectDependencies(root, root, []string{}, nil, deps, paths)
+
+	s := &schedule{
+		queued: map[*scheduledItem]bool{},
+		exact:  map[string]map[*scheduledItem]bool{},
+		within: map[string]map[*scheduledItem]bool{},
+	}
+
+	for _, component := range stronglyConnectedComponents(deps) {
+		for _, key := range component {
+			item := &scheduledItem{path: paths[key], order: len(s.queue)}
+
+			s.watch(item, nil)
+			s.enqueue(item)
+		}
+	}
+
+	return s
+}
+
+func (s *schedule) enqueue(item *scheduledItem) {
+	if s.queued[item] {
+		return
+	}
+
+	s.queued[item] = true
+	s.queue = append(s.queue, item)
+}
+
+func (s *schedule) next() (*scheduledItem, bool) {
+	if len(s.queue) == 0 {
+		return nil, false
+	}
+
+	item := s.queue[0]
+	s.queue = s.queue[1:]
+	s.queued[item] = false
+
+	return item, true
+}
+
+// watch replaces the paths the item is woken by with its own path and the
+// given lookups.
+func (s *schedule) watch(item *scheduledItem, lookups [][]string) {
+	for _, path := range item.watched {
+		s.index(item, path, false)
+	}
+
+	item.watched = append([][]string{item.path}, lookups...)
+
+	for _, path := range item.watched {
+		s.index(item, path, true)
+	}
+}
+
+func (s *schedule) index(item *scheduledItem, path []string, watching bool) {
+	for i := 0; i < len(path); i++ {
+		mark(s.within, pathKey(path[:i]), item, watching)
+	}
+
+	mark(s.exact, pathKey(path), item, watching)
+}
+
+func mark(index map[string]map[*scheduledItem]bool, key string, item *scheduledItem, watching bool) {
+	items := index[key]
+
+	if !watching {
+		delete(items, item)
+		return
+	}
+
+	if items == nil {
+		items = map[*scheduledItem]bool{}
+		index[key] = items
+	}
+
+	items[item] = true
+}
+
+// changed queues the node at the given path again, as its new value may
+// need to be flowed further, along with every node that looked it up, a
+// node below it or a node containing it. They are queued in the order of
+// their dependencies.
+func (s *schedule) changed(path []string) {
+	woken := []*scheduledItem{}
+
+	collect := func(items map[*scheduledItem]bool) {
+		for item := range items {
+			woken = append(woken, item)
+		}
+	}
+
+	collect(s.exact[pathKey(path)])
+	collect(s.within[pathKey(path)])
+
+	for i := 0; i < len(path); i++ {
+		collect(s.exact[pathKey(path[:i])])
+	}
+
+	sort.Sort(byOrder(woken))
+
+	for _, item := range woken {
+		s.enqueue(item)
+	}
+}
+
+type byOrder []*scheduledItem
+
+func (items byOrder) Len() int           { return len(items) }
+func (items byOrder) Swap(i, j int)      { items[i], items[j] = items[j], items[i] }
+func (items byOrder) Less(i, j int) bool { return items[i].order < items[j].order }
+
+func pathKey(path []string) string {
+	return strings.Join(path, "\x00")
+}
+
+// lookupRecorder collects the paths looked up while flowing a node. Steps
+// through lists are recorded by the name used for the paths of the tree.
+type lookupRecorder struct {
+	root  yaml.Node
+	paths [][]string
+}
+
+func (r *lookupRecorder) record(path []string) {
+	canonical := make([]string, 0, len(path))
+
+	here := r.root
+	for i, step := range path {
+		next, name, found := canonicalStep(here, step)
+		if !found {
+			canonical = append(canonical, path[i:]...)
+			break
+		}
+
+		canonical = append(canonical, name)
+		here = next
+	}
+
+	r.paths = append(r.paths, canonical)
+}
+
+// locate finds the node at the given path along with the environment and
+// override mode it is flowed with.
+func locate(root yaml.Node, path []string, env Environment) (yaml.Node, Environment, bool, bool) {
+	here := root
+	override := true
+
+	for _, step := range path {
+		if here == nil {
+			return nil, env, false, false
+		}
+
+		if here.NoMerge() {
+			env = env.withoutMerge()
+		}
+
+		switch val := here.Value().(type) {
+		case map[string]yaml.Node:
+			next, found := val[step]
+			if !found {
+				return nil, env, false, false
+			}
+
+			env = env.WithScope(val).WithPath(step)
+			override = true
+			here = next
+
+		case []yaml.Node:
+			index := listIndex(val, here.KeyName(), step)
+			if index < 0 {
+				return nil, env, false, false
+			}
+
+			env = env.withListPath(step, here.KeyName())
+			override = false
+			here = val[index]
+
+		default:
+			return nil, env, false, false
+		}
+	}
+
+	return here, env, override, true
+}
+
+// copies tracks the maps and lists evaluate copied to replace the nodes
+// below them, by their path. Nothing else refers to them, so they are
+// changed in place by further replacements, until a lookup may have handed
+// them out.
+type copies struct {
+	owned    bool
+	children map[string]*copies
+}
+
+func (c *copies) child(step string) *copies {
+	if c.children == nil {
+		c.children = map[string]*copies{}
+	}
+
+	next := c.children[step]
+	if next == nil {
+		next = &copies{}
+		c.children[step] = next
+	}
+
+	return next
+}
+
+// disown forgets the copies at and below the given path.
+func (c *copies) disown(path []string) {
+	if len(path) == 0 {
+		c.owned = false
+		c.children = nil
+		return
+	}
+
+	here := c
+	for _, step := range path[:len(path)-1] {
+		here = here.children[step]
+		if here == nil {
+			return
+		}
+	}
+
+	delete(here.children, path[len(path)-1])
+}
+
+// replaceNode returns the tree with the node at the given path replaced. The
+// maps and lists along the path are copied, unless they are copies made by
+// earlier replacements, leaving the original tree untouched.
+func replaceNode(root yaml.Node, path []string, replacement yaml.Node, copies *copies) yaml.Node {
+	if len(path) == 0 {
+		copies.disown(path)
+		return replacement
+	}
+
+	switch val := root.Value().(type) {
+	case map[string]yaml.Node:
+		if !copies.owned {
+			newMap := make(map[string]yaml.Node, len(val))
+			for key, sub := range val {
+				newMap[key] = sub
+			}
+
+			val = newMap
+			root = yaml.SubstituteNode(newMap, root)
+			copies.owned = true
+		}
+
+		val[path[0]] = replaceNode(val[path[0]], path[1:], replacement, copies.child(path[0]))
+
+		return root
+
+	case []yaml.Node:
+		index := listIndex(val, root.KeyName(), path[0])
+		if index < 0 {
+			return root
+		}
+
+		if !copies.owned {
+			newList := make([]yaml.Node, len(val))
+			copy(newList, val)
+
+			val = newList
+			root = yaml.SubstituteNode(newList, root)
+			copies.owned = true
+		}
+
+		val[index] = replaceNode(val[index], path[1:], replacement, copies.child(path[0]))
+
+		return root
+	}
+
+	return root
+}
+
+func listIndex(list []yaml.Node, keyName string, step string) int {
+	for i, sub := range list {
+		if stepName(i, sub, keyName) == step {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,130 @@
+package flow
+
+import (
+	"io/ioutil"
+	"reflect"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+// flowUntilStable flows a template pass by pass until nothing changes
+// anymore, which is what the evaluation order must agree with.
+func flowUntilStable(source yaml.Node, stubs ...yaml.Node) yaml.Node {
+	result := source
+
+	for {
+		next := flow(result, Environment{Stubs: stubs}, true)
+
+		if reflect.DeepEqual(result, next) {
+			return result
+		}
+
+		result = next
+	}
+}
+
+func parseExample(name string) yaml.Node {
+	source, err := ioutil.ReadFile("../examples/" + name)
+	if err != nil {
+		panic(err)
+	}
+
+	parsed, err := yaml.Parse(name, source)
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed
+}
+
+var _ = Describe("Evaluating in dependency order", func() {
+	It("agrees with flowing pass by pass", func() {
+		template := parseExample("cf-aws.yml")
+		stub := parseExample("cf-aws-stub.yml")
+
+		result, err := Flow(template, stub)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(result).To(Equal(flowUntilStable(template, stub)))
+	})
+
+	It("resolves references regardless of their order in the template", func() {
+		source := parseYAML(`
+---
+a: (( b ))
+b: (( c ))
+c: (( d ))
+d: 42
+`)
+
+		resolved := parseYAML(`
+---
+a: 42
+b: 42
+c: 42
+d: 42
+`)
+
+		Expect(source).To(FlowAs(resolved))
+	})
+
+	It("re-evaluates nodes once the nodes they looked up change", func() {
+		source := parseYAML(`
+---
+resource_pools:
+- name: small
+  size: (( auto ))
+jobs:
+- name: a
+  resource_pool: small
+  instances: (( meta.count ))
+meta:
+  count: (( merge ))
+`)
+
+		stub := parseYAML(`
+---
+meta:
+  count: 3
+`)
+
+		resolved := parseYAML(`
+---
+resource_pools:
+- name: small
+  size: 3
+jobs:
+- name: a
+  resource_pool: small
+  instances: 3
+meta:
+  count: 3
+`)
+
+		Expect(source).To(FlowAs(resolved, stub))
+	})
+
+	It("flows dynaml nodes that are copied along with their parent", func() {
+		source := parseYAML(`
+---
+a: (( b ))
+b:
+  c: (( d ))
+d: 42
+`)
+
+		resolved := parseYAML(`
+---
+a:
+  c: 42
+b:
+  c: 42
+d: 42
+`)
+
+		Expect(source).To(FlowAs(resolved))
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"regexp"
 	"sort"
+	"strings"
 
 	"github.com/cloudfoundry-incubator/spiff/dynaml"
 	"github.com/cloudfoundry-incubator/spiff/yaml"
@@ -13,10 +14,48 @@ import (
 var embeddedDynaml = regexp.MustCompile(`^\(\((.*)\)\)$`)
 
 func Flow(source yaml.Node, stubs ...yaml.Node) (yaml.Node, error) {
-	result := source
+	return Environment{}.Flow(source, stubs...)
+}
+
+// Flow flows the source with the stubs like the function Flow, making the
+// environment's variables available to it.
+func (e Environment) Flow(source yaml.Node, stubs ...yaml.Node) (yaml.Node, error) {
+	result, err := e.withExecCache().flowStub(source, stubs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return prune(result), nil
+}
+
+// flowStub flows a stub the same way as Flow, but keeps its (( delete ))
+// markers, as they are meant for the templates the stub is merged into, and
+// its temporary nodes.
+func (e Environment) flowStub(source yaml.Node, stubs ...yaml.Node) (yaml.Node, error) {
+	env := Environment{
+		Stubs:      stubs,
+		Variables:  e.Variables,
+		EnableExec: e.EnableExec,
+		execCache:  e.execCache,
+	}
+
+	// the first pass parses the dynaml nodes and merges in the stubs; after
+	// that, nodes are evaluated in the order of their dependencies. Only if
+	// that leaves nodes unresolved, a full pass makes sure they cannot be
+	// resolved by flowing them once more.
+	result := flow(source, env, true)
+
+	var unresolved []UnresolvedNode
 
 	for {
-		next := flow(result, Environment{Stubs: stubs}, true)
+		result = evaluate(result, env)
+
+		unresolved = findUnresolvedNodes(result)
+		if len(unresolved) == 0 {
+			break
+		}
+
+		next := flow(result, env, true)
 
 		if reflect.DeepEqual(result, next) {
 			break
@@ -25,8 +64,15 @@ func Flow(source yaml.Node, stubs ...yaml.Node) (yaml.Node, error) {
 		result = next
 	}
 
-	unresolved := findUnresolvedNodes(result)
 	if len(unresolved) > 0 {
+		cycles := findReferenceCycles(result)
+		if len(cycles) > 0 {
+			return nil, ReferenceCycles{
+				Cycles:     cycles,
+				Unresolved: outsideCycles(result, unresolved, cycles),
+			}
+		}
+
 		return nil, UnresolvedNodes{unresolved}
 	}
 
@@ -46,18 +92,26 @@ func flow(root yaml.Node, env Environment, shouldOverride bool) yaml.Node {
 		return flowList(root, env)
 
 	case dynaml.Expression:
-		result, ok := val.Evaluate(env)
+		result, info, ok := val.Evaluate(env.WithOrigin(root.Origin()))
 		if !ok {
-			return root
+			return yaml.IssueNode(root, info.Issue)
+		}
+
+		// values taken from temporary nodes are not temporary themselves
+		_, temporary := val.(dynaml.TemporaryExpr)
+		result = yaml.TemporaryNode(result, temporary)
+
+		if info.Issue != "" {
+			return yaml.IssueNode(result, info.Issue)
 		}
 
 		return result
 	}
 
-	if shouldOverride {
+	if shouldOverride && !env.noMerge {
 		overridden, found := env.FindInStubs(env.Path)
 		if found {
-			return overridden
+			return yaml.TemporaryNode(overridden, isTemporary(root))
 		}
 	}
 
@@ -74,6 +128,11 @@ func flowMap(root yaml.Node, env Environment) yaml.Node {
 
 	env = env.WithScope(rootMap)
 
+	noMerge := root.NoMerge()
+	if noMerge {
+		env = env.withoutMerge()
+	}
+
 	newMap := make(map[string]yaml.Node)
 
 	sortedKeys := getSortedKeys(rootMap)
@@ -84,39 +143,92 @@ func flowMap(root yaml.Node, env Environment) yaml.Node {
 		val := rootMap[key]
 
 		if key == "<<" {
-			base := flow(val, env, true)
+			base, merge := flowInline(val, env)
+			if merge.None {
+				noMerge = true
+				env = env.withoutMerge()
+				continue
+			}
+
+			if deleted(base) {
+				newMap[key] = base
+				continue
+			}
+
+			if base.Temporary() {
+				root = yaml.TemporaryNode(root, true)
+			}
+
 			baseMap, ok := base.Value().(map[string]yaml.Node)
+			if ok && merge.Replace {
+				return yaml.SubstituteNode(baseMap, root)
+			}
+
+			// the spliced keys are overridden by the stubs like the
+			// keys of the map itself
 			if ok {
 				for k, v := range baseMap {
-					newMap[k] = v
+					keyEnv := env.WithPath(k)
+					if keyEnv.deletedInStubs() {
+						continue
+					}
+
+					newMap[k] = flow(v, keyEnv, true)
 				}
 			}
 
 			continue
 		}
 
-		newMap[key] = flow(val, env.WithPath(key), true)
+		keyEnv := env.WithPath(key)
+		if keyEnv.deletedInStubs() {
+			continue
+		}
+
+		newMap[key] = flow(val, keyEnv, true)
 	}
 
-	return yaml.NewNode(newMap, root.SourceName())
+	if noMerge {
+		return yaml.NoMergeNode(yaml.SubstituteNode(newMap, root))
+	}
+
+	return yaml.SubstituteNode(newMap, root)
 }
 
 func flowList(root yaml.Node, env Environment) yaml.Node {
-	rootList := root.Value().([]yaml.Node)
+	if root.NoMerge() {
+		env = env.withoutMerge()
+	}
 
-	merged := processMerges(rootList, env)
+	merged := processMerges(root, env)
+	if merged.NoMerge() {
+		env = env.withoutMerge()
+	}
+
+	keyName := merged.KeyName()
 
 	newList := []yaml.Node{}
 
-	for idx, val := range merged {
-		step := stepName(idx, val)
-		newList = append(newList, flow(val, env.WithPath(step), false))
+	for idx, val := range merged.Value().([]yaml.Node) {
+		step := stepName(idx, val, keyName)
+		entryEnv := env.withListPath(step, keyName)
+
+		_, named := yaml.FindString(val, keyName)
+		if named && entryEnv.deletedInStubs() {
+			continue
+		}
+
+		newList = append(newList, flow(val, entryEnv, false))
 	}
 
-	return yaml.NewNode(newList, root.SourceName())
+	return yaml.SubstituteNode(newList, merged)
 }
 
 func flowString(root yaml.Node, env Environment) yaml.Node {
+	if root.Literal() {
+		return root
+	}
+
 	rootString := root.Value().(string)
 
 	sub := embeddedDynaml.FindStringSubmatch(rootString)
@@ -124,16 +236,43 @@ func flowString(root yaml.Node, env Environment) yaml.Node {
 		return root
 	}
 
+	// a leading '!' marks nodes that are to be left alone; negations have
+	// to be grouped to appear at the beginning of an expression
+	if strings.HasPrefix(strings.TrimSpace(sub[1]), "!") {
+		return root
+	}
+
 	expr, err := dynaml.Parse(sub[1], env.Path)
 	if err != nil {
 		return root
 	}
 
-	return yaml.NewNode(expr, root.SourceName())
+	return yaml.SubstituteNode(expr, root)
+}
+
+// flowInline evaluates the value of a "<<" key right away, as it is spliced
+// into the surrounding map or list rather than kept. It is not overridden by
+// the stubs, so that (( merge other.path )) takes the value from other.path
+// instead of the path of the surrounding node. The merge expression, if any,
+// is returned as well, as it tells how the value is to be spliced in.
+func flowInline(root yaml.Node, env Environment) (yaml.Node, dynaml.MergeExpr) {
+	inline := flow(root, env, false)
+
+	merge, _ := inline.Value().(dynaml.MergeExpr)
+	if merge.None {
+		return inline, merge
+	}
+
+	_, ok := inline.Value().(dynaml.Expression)
+	if ok {
+		inline = flow(inline, env, false)
+	}
+
+	return inline, merge
 }
 
-func stepName(index int, value yaml.Node) string {
-	name, ok := yaml.FindString(value, "name")
+func stepName(index int, value yaml.Node, keyName string) string {
+	name, ok := yaml.FindString(value, keyName)
 	if ok {
 		return name
 	}
@@ -141,10 +280,15 @@ func stepName(index int, value yaml.Node) string {
 	return fmt.Sprintf("[%d]", index)
 }
 
-func processMerges(root []yaml.Node, env Environment) []yaml.Node {
+// processMerges splices the lists of "<<" entries into the list, returning
+// it marked with the way they were merged, i.e. (( merge none )) and the key
+// name given with (( merge on key )).
+func processMerges(root yaml.Node, env Environment) yaml.Node {
+	rootList := root.Value().([]yaml.Node)
+
 	spliced := []yaml.Node{}
 
-	for _, val := range root {
+	for _, val := range rootList {
 		if val == nil {
 			continue
 		}
@@ -154,10 +298,28 @@ func processMerges(root []yaml.Node, env Environment) []yaml.Node {
 			if len(subMap) == 1 {
 				inlineNode, ok := subMap["<<"]
 				if ok {
-					inline, ok := flow(inlineNode, env, true).Value().([]yaml.Node)
+					inline, merge := flowInline(inlineNode, env)
+					if merge.KeyName != "" {
+						root = yaml.KeyNameNode(root, merge.KeyName)
+					}
+
+					if merge.None {
+						root = yaml.NoMergeNode(root)
+						continue
+					}
+
+					if inline.Temporary() {
+						root = yaml.TemporaryNode(root, true)
+						continue
+					}
+
+					inlineList, ok := inline.Value().([]yaml.Node)
+					if ok && merge.Replace {
+						return yaml.SubstituteNode(inlineList, root)
+					}
 
 					if ok {
-						inlineNew := newEntries(inline, root)
+						inlineNew := newEntries(inlineList, rootList, root.KeyName())
 						spliced = append(spliced, inlineNew...)
 						continue
 					}
@@ -168,19 +330,16 @@ func processMerges(root []yaml.Node, env Environment) []yaml.Node {
 		spliced = append(spliced, val)
 	}
 
-	return spliced
+	return yaml.SubstituteNode(spliced, root)
 }
 
-func newEntries(a []yaml.Node, b []yaml.Node) []yaml.Node {
+func newEntries(a []yaml.Node, b []yaml.Node, keyName string) []yaml.Node {
 	added := []yaml.Node{}
 
 	for _, val := range a {
-		name, ok := yaml.FindString(val, "name")
-		if ok {
-			_, found := yaml.Find(yaml.NewNode(b, "some map"), name) // TODO
-			if found {
-				continue
-			}
+		name, ok := yaml.FindString(val, keyName)
+		if ok && containsEntry(b, keyName, name) {
+			continue
 		}
 
 		added = append(added, val)
@@ -189,6 +348,102 @@ func newEntries(a []yaml.Node, b []yaml.Node) []yaml.Node {
 	return added
 }
 
+// containsEntry tells whether the list has an entry with the given name
+// under the key name.
+func containsEntry(list []yaml.Node, keyName string, name string) bool {
+	for _, entry := range list {
+		entryName, ok := yaml.FindString(entry, keyName)
+		if ok && entryName == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// deleted tells whether a node is marked for deletion, either by being
+// (( delete )) or, for list entries that have to keep their name, by having
+// "<<: (( delete ))". Stubs that are not flowed still hold the marker as a
+// string.
+func deleted(node yaml.Node) bool {
+	if node == nil {
+		return false
+	}
+
+	switch val := node.Value().(type) {
+	case dynaml.DeleteExpr:
+		return true
+
+	case string:
+		sub := embeddedDynaml.FindStringSubmatch(val)
+		return sub != nil && strings.TrimSpace(sub[1]) == "delete"
+
+	case map[string]yaml.Node:
+		return deleted(val["<<"])
+	}
+
+	return false
+}
+
+// isTemporary tells whether a node is marked with (( &temporary )), also
+// before it is parsed.
+func isTemporary(node yaml.Node) bool {
+	if node.Temporary() {
+		return true
+	}
+
+	str, ok := node.Value().(string)
+	if !ok || node.Literal() {
+		return false
+	}
+
+	sub := embeddedDynaml.FindStringSubmatch(str)
+	if sub == nil {
+		return false
+	}
+
+	expr, err := dynaml.Parse(sub[1], nil)
+	if err != nil {
+		return false
+	}
+
+	_, ok = expr.(dynaml.TemporaryExpr)
+	return ok
+}
+
+// prune removes the map keys and list entries that are not part of the
+// output once the template is fully flowed, i.e. those still marked for
+// deletion and the temporary ones.
+func prune(root yaml.Node) yaml.Node {
+	if root == nil {
+		return root
+	}
+
+	switch val := root.Value().(type) {
+	case map[string]yaml.Node:
+		newMap := make(map[string]yaml.Node)
+		for key, sub := range val {
+			if !deleted(sub) && !sub.Temporary() {
+				newMap[key] = prune(sub)
+			}
+		}
+
+		return yaml.SubstituteNode(newMap, root)
+
+	case []yaml.Node:
+		newList := []yaml.Node{}
+		for _, sub := range val {
+			if !deleted(sub) && !sub.Temporary() {
+				newList = append(newList, prune(sub))
+			}
+		}
+
+		return yaml.SubstituteNode(newList, root)
+	}
+
+	return root
+}
+
 func getSortedKeys(unsortedMap map[string]yaml.Node) []string {
 	keys := make([]string, len(unsortedMap))
 	i := 0
@@ -28,13 +28,7 @@ func (matcher *FlowAsMatcher) Match(source interface{}) (success bool, err error
 		return false, err
 	}
 
-	if matcher.actual.EquivalentToNode(matcher.Expected) {
-		return true, nil
-	} else {
-		return false, nil
-	}
-
-	return
+	return matcher.actual.EquivalentToNode(matcher.Expected), nil
 }
 
 func formatMessage(actual yaml.Node, message string, expected yaml.Node) string {
@@ -0,0 +1,71 @@
+package flow
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+func BenchmarkFlowCFAWS(b *testing.B) {
+	template := parseExample("cf-aws.yml")
+	stub := parseExample("cf-aws-stub.yml")
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := Flow(template, stub)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCascadeCFAWS(b *testing.B) {
+	template := parseExample("cf-aws.yml")
+	stub := parseExample("cf-aws-stub.yml")
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := Cascade(template, stub)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFlowReferenceChain(b *testing.B) {
+	for _, length := range []int{10, 100, 500} {
+		source := referenceChain(length)
+
+		b.Run(fmt.Sprintf("%d", length), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := Flow(source)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// referenceChain builds a template in which every value refers to the next
+// one, so that they can only be resolved one after the other.
+func referenceChain(length int) yaml.Node {
+	lines := []string{"---"}
+
+	for i := 0; i < length; i++ {
+		lines = append(lines, fmt.Sprintf("key%04d: (( key%04d ))", i, i+1))
+	}
+
+	lines = append(lines, fmt.Sprintf("key%04d: 42", length))
+
+	parsed, err := yaml.Parse("chain", []byte(strings.Join(lines, "\n")))
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed
+}
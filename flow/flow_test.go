@@ -91,9 +91,12 @@ foo: (( auto ))
 			Expect(err).To(Equal(UnresolvedNodes{
 				Nodes: []UnresolvedNode{
 					{
-						Node: yaml.NewNode(
-							dynaml.AutoExpr{Path: []string{"foo"}},
-							"test",
+						Node: yaml.IssueNode(
+							yaml.NewNodeWithOrigin(
+								dynaml.AutoExpr{Path: []string{"foo"}},
+								yaml.Origin{SourceName: "test", Line: 3, Column: 6},
+							),
+							"auto is not supported for foo",
 						),
 						Context: []string{"foo"},
 						Path:    []string{"foo"},
@@ -113,6 +116,33 @@ foo: ((!template_only.foo))
 			resolved := parseYAML(`
 ---
 foo: ((!template_only.foo))
+`)
+
+			Expect(source).To(FlowAs(resolved))
+		})
+
+		It("ignores nodes with whitespace before the '!'", func() {
+			source := parseYAML(`
+---
+foo: (( !template_only.foo ))
+`)
+
+			Expect(source).To(FlowAs(source))
+		})
+
+		It("evaluates grouped negations", func() {
+			source := parseYAML(`
+---
+enabled: false
+foo: (( (!enabled) ? "off" :"on" ))
+bar: '(( enabled ? "on" : "off" ))'
+`)
+
+			resolved := parseYAML(`
+---
+enabled: false
+foo: "off"
+bar: "off"
 `)
 
 			Expect(source).To(FlowAs(resolved))
@@ -159,6 +189,83 @@ properties:
 			_, err := Flow(source)
 			Expect(err).To(HaveOccurred())
 		})
+
+		It("reports the root cause", func() {
+			source := parseYAML(`
+---
+networks:
+  cf1:
+    name: cf1
+size: (( networks.cf1.subnets ))
+`)
+
+			_, err := Flow(source)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("networks.cf1.subnets not found"))
+		})
+
+		It("reports the unresolved operand rather than a type mismatch", func() {
+			source := parseYAML(`
+---
+sum: (( size + 1 ))
+name: (( size "x" ))
+size: (( nope ))
+`)
+
+			_, err := Flow(source)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("nope not found"))
+			Expect(err.Error()).To(ContainSubstring("size is not resolved yet"))
+			Expect(err.Error()).NotTo(ContainSubstring("cannot"))
+		})
+	})
+
+	Context("when references form a cycle", func() {
+		It("reports the cycle", func() {
+			source := parseYAML(`
+---
+meta:
+  size: (( jobs.api.instances ))
+jobs:
+  api:
+    instances: (( meta.size ))
+`)
+
+			_, err := Flow(source)
+			Expect(err).To(Equal(ReferenceCycles{
+				Cycles: [][]string{
+					{"jobs.api.instances", "meta.size", "jobs.api.instances"},
+				},
+			}))
+		})
+
+		It("reports the other unresolved nodes along with it", func() {
+			source := parseYAML(`
+---
+meta:
+  size: (( jobs.api.instances ))
+jobs:
+- name: api
+  instances: (( meta.size ))
+- name: worker
+  instances: (( meta.missing ))
+`)
+
+			_, err := Flow(source)
+			Expect(err).To(HaveOccurred())
+
+			cycles, ok := err.(ReferenceCycles)
+			Expect(ok).To(BeTrue())
+			Expect(cycles.Cycles).To(Equal([][]string{
+				{"jobs.api.instances", "meta.size", "jobs.api.instances"},
+			}))
+
+			Expect(cycles.Unresolved).To(HaveLen(1))
+			Expect(cycles.Unresolved[0].Context).To(Equal([]string{"jobs", "[1]", "instances"}))
+
+			Expect(err.Error()).To(ContainSubstring("unresolved nodes:"))
+			Expect(err.Error()).To(ContainSubstring("meta.missing"))
+		})
 	})
 
 	Context("when a reference is made to an unresolveable node, in a || expression", func() {
@@ -194,6 +301,7 @@ properties:
 foo:
   - (( "hello, world!" ))
   - (( 42 ))
+  - (( 4.2 ))
   - (( true ))
   - (( nil ))
 `)
@@ -203,6 +311,7 @@ foo:
 foo:
   - hello, world!
   - 42
+  - 4.2
   - true
   - null
 `)
@@ -211,6 +320,146 @@ foo:
 		})
 	})
 
+	Describe("map literals", func() {
+		It("evaluate to maps", func() {
+			source := parseYAML(`
+---
+port: 8080
+server: (( merge || { "port":port, "tls":false } ))
+`)
+
+			resolved := parseYAML(`
+---
+port: 8080
+server:
+  port: 8080
+  tls: false
+`)
+
+			Expect(source).To(FlowAs(resolved))
+		})
+	})
+
+	Describe("string functions", func() {
+		It("evaluate their arguments", func() {
+			source := parseYAML(`
+---
+hosts:
+  - a.example.com
+  - b.example.com
+servers: (( join(",", hosts) ))
+url: (( format("https://%s:%d", hosts.[0], 443) ))
+count: (( length(hosts) ))
+`)
+
+			resolved := parseYAML(`
+---
+hosts:
+  - a.example.com
+  - b.example.com
+servers: a.example.com,b.example.com
+url: https://a.example.com:443
+count: 2
+`)
+
+			Expect(source).To(FlowAs(resolved))
+		})
+
+		It("reports unknown functions", func() {
+			source := parseYAML(`
+---
+foo: (( frobnicate(1) ))
+`)
+
+			_, err := Flow(source)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unknown function 'frobnicate'"))
+		})
+	})
+
+	Describe("list functions", func() {
+		It("evaluate lambdas for the entries of lists", func() {
+			source := parseYAML(`
+---
+jobs:
+  - name: api
+    instances: 2
+    az: z1
+  - name: router
+    instances: (( base + 1 ))
+    az: z2
+base: 2
+names: (( map[jobs|job|->job.name] ))
+z1: (( map[filter[jobs|job|->job.az == "z1"]|job|->job.name] ))
+total: (( sum[jobs|job|->job.instances] ))
+`)
+
+			resolved := parseYAML(`
+---
+jobs:
+  - name: api
+    instances: 2
+    az: z1
+  - name: router
+    instances: 3
+    az: z2
+base: 2
+names:
+  - api
+  - router
+z1:
+  - api
+total: 5
+`)
+
+			Expect(source).To(FlowAs(resolved))
+		})
+	})
+
+	Describe("arithmetic dynaml nodes", func() {
+		It("promotes ints to floats when mixed with floats", func() {
+			source := parseYAML(`
+---
+ratio: 0.25
+instances: 4
+sum: (( ratio + 0.5 ))
+share: (( instances * ratio ))
+half: (( instances / 2 ))
+label: (( "ratio " ratio ))
+`)
+
+			resolved := parseYAML(`
+---
+ratio: 0.25
+instances: 4
+sum: 0.75
+share: 1.0
+half: 2
+label: ratio 0.25
+`)
+
+			Expect(source).To(FlowAs(resolved))
+		})
+	})
+
+	Describe("nodes created by dynaml", func() {
+		It("originate from the expression that produced them", func() {
+			source := parseYAML(`
+---
+foo:
+  bar: (( "hello, " "world!" ))
+`)
+
+			flowed, err := Flow(source)
+			Expect(err).NotTo(HaveOccurred())
+
+			bar, found := yaml.Find(flowed, "foo", "bar")
+			Expect(found).To(BeTrue())
+			Expect(bar.Value()).To(Equal("hello, world!"))
+			Expect(bar.Origin()).To(Equal(yaml.Origin{SourceName: "test", Line: 4, Column: 8}))
+		})
+	})
+
 	Describe("reference dynaml nodes", func() {
 		It("evaluates the node", func() {
 			source := parseYAML(`
@@ -442,6 +691,52 @@ jobs:
 		})
 	})
 
+	Describe("automatic update and static ip settings", func() {
+		It("evaluates the nodes", func() {
+			source := parseYAML(`
+---
+update:
+  max_in_flight: (( auto ))
+networks:
+- name: cf1
+  subnets:
+  - static:
+    - 10.10.16.10 - 10.10.16.20
+jobs:
+- name: router
+  instances: 2
+  networks:
+  - name: cf1
+    static_ips: (( auto ))
+- name: runner
+  instances: (( 2 * 5 ))
+`)
+
+			resolved := parseYAML(`
+---
+update:
+  max_in_flight: 3
+networks:
+- name: cf1
+  subnets:
+  - static:
+    - 10.10.16.10 - 10.10.16.20
+jobs:
+- name: router
+  instances: 2
+  networks:
+  - name: cf1
+    static_ips:
+    - 10.10.16.10
+    - 10.10.16.11
+- name: runner
+  instances: 10
+`)
+
+			Expect(source).To(FlowAs(resolved))
+		})
+	})
+
 	Describe("static ip population", func() {
 		It("evaluates the node", func() {
 			source := parseYAML(`
@@ -543,6 +838,588 @@ properties:
 		})
 	})
 
+	Describe("merging from another path", func() {
+		stub := parseYAML(`
+---
+old:
+  location: here
+  db:
+    host: db.example.com
+    port: 1234
+  jobs:
+  - name: extra
+    instances: 2
+  - name: fixed
+    instances: 5
+`)
+
+		It("takes the value from the given path in the stubs", func() {
+			source := parseYAML(`
+---
+location: (( merge old.location ))
+`)
+
+			resolved := parseYAML(`
+---
+location: here
+`)
+
+			Expect(source).To(FlowAs(resolved, stub))
+		})
+
+		It("splices maps from the given path", func() {
+			source := parseYAML(`
+---
+db:
+  <<: (( merge old.db ))
+  port: 5432
+`)
+
+			resolved := parseYAML(`
+---
+db:
+  host: db.example.com
+  port: 5432
+`)
+
+			Expect(source).To(FlowAs(resolved, stub))
+		})
+
+		It("splices from the given path, while stubs still override the keys", func() {
+			source := parseYAML(`
+---
+db:
+  <<: (( merge old.db ))
+`)
+
+			otherStub := parseYAML(`
+---
+db:
+  host: other.example.com
+`)
+
+			resolved := parseYAML(`
+---
+db:
+  host: other.example.com
+  port: 1234
+`)
+
+			Expect(source).To(FlowAs(resolved, stub, otherStub))
+		})
+
+		It("splices lists from the given path", func() {
+			source := parseYAML(`
+---
+jobs:
+- name: fixed
+  instances: 1
+- <<: (( merge old.jobs ))
+`)
+
+			resolved := parseYAML(`
+---
+jobs:
+- name: fixed
+  instances: 1
+- name: extra
+  instances: 2
+`)
+
+			Expect(source).To(FlowAs(resolved, stub))
+		})
+
+		It("fails if the path is not found in any stub", func() {
+			source := parseYAML(`
+---
+location: (( merge new.location ))
+`)
+
+			_, err := Flow(source, stub)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("new.location not found in any stub"))
+		})
+	})
+
+	Describe("replacing merges", func() {
+		It("takes a map from the stubs as a whole", func() {
+			source := parseYAML(`
+---
+foo:
+  <<: (( merge replace ))
+  a: 1
+  c: 3
+`)
+
+			stub := parseYAML(`
+---
+foo:
+  a: 2
+  b: 2
+`)
+
+			resolved := parseYAML(`
+---
+foo:
+  a: 2
+  b: 2
+`)
+
+			Expect(source).To(FlowAs(resolved, stub))
+		})
+
+		It("keeps the template's entries if no stub provides the map", func() {
+			source := parseYAML(`
+---
+foo:
+  <<: (( merge replace ))
+  a: 1
+`)
+
+			resolved := parseYAML(`
+---
+foo:
+  a: 1
+`)
+
+			Expect(source).To(FlowAs(resolved))
+		})
+
+		It("takes a map from another path", func() {
+			source := parseYAML(`
+---
+foo:
+  <<: (( merge replace old.foo ))
+  a: 1
+`)
+
+			stub := parseYAML(`
+---
+old:
+  foo:
+    b: 2
+`)
+
+			resolved := parseYAML(`
+---
+foo:
+  b: 2
+`)
+
+			Expect(source).To(FlowAs(resolved, stub))
+		})
+
+		It("takes a list from the stubs as a whole", func() {
+			source := parseYAML(`
+---
+foo:
+  - a
+  - <<: (( merge replace ))
+  - b
+`)
+
+			stub := parseYAML(`
+---
+foo:
+  - c
+  - d
+`)
+
+			resolved := parseYAML(`
+---
+foo:
+  - c
+  - d
+`)
+
+			Expect(source).To(FlowAs(resolved, stub))
+		})
+	})
+
+	Describe("merging none", func() {
+		It("keeps the stubs from overriding a map", func() {
+			source := parseYAML(`
+---
+foo:
+  <<: (( merge none ))
+  a: 1
+  bar:
+    b: 1
+`)
+
+			stub := parseYAML(`
+---
+foo:
+  a: 2
+  bar:
+    b: 2
+  c: 3
+`)
+
+			resolved := parseYAML(`
+---
+foo:
+  a: 1
+  bar:
+    b: 1
+`)
+
+			Expect(source).To(FlowAs(resolved, stub))
+		})
+
+		It("keeps the stubs out after the first pass", func() {
+			source := parseYAML(`
+---
+foo:
+  <<: (( merge none ))
+  a: (( b ))
+  b: 1
+`)
+
+			stub := parseYAML(`
+---
+foo:
+  a: 5
+  b: 2
+`)
+
+			resolved := parseYAML(`
+---
+foo:
+  a: 1
+  b: 1
+`)
+
+			Expect(source).To(FlowAs(resolved, stub))
+		})
+
+		It("still allows explicit merges", func() {
+			source := parseYAML(`
+---
+foo:
+  <<: (( merge none ))
+  a: (( merge ))
+  b: 1
+`)
+
+			stub := parseYAML(`
+---
+foo:
+  a: 2
+  b: 2
+`)
+
+			resolved := parseYAML(`
+---
+foo:
+  a: 2
+  b: 1
+`)
+
+			Expect(source).To(FlowAs(resolved, stub))
+		})
+
+		It("splices nothing into a list", func() {
+			source := parseYAML(`
+---
+foo:
+  - name: a
+    value: 1
+  - <<: (( merge none ))
+`)
+
+			stub := parseYAML(`
+---
+foo:
+  - name: a
+    value: 2
+  - name: b
+    value: 2
+`)
+
+			resolved := parseYAML(`
+---
+foo:
+  - name: a
+    value: 1
+`)
+
+			Expect(source).To(FlowAs(resolved, stub))
+		})
+	})
+
+	Describe("merging lists on a key", func() {
+		stub := parseYAML(`
+---
+variables:
+  - key: password
+    type: secret
+  - key: cert
+    type: certificate
+`)
+
+		It("identifies the entries by the key", func() {
+			source := parseYAML(`
+---
+variables:
+  - key: password
+    type: plain
+  - <<: (( merge on key ))
+`)
+
+			resolved := parseYAML(`
+---
+variables:
+  - key: password
+    type: secret
+  - key: cert
+    type: certificate
+`)
+
+			Expect(source).To(FlowAs(resolved, stub))
+		})
+
+		It("steps through the list by the key", func() {
+			source := parseYAML(`
+---
+variables:
+  - key: password
+    type: plain
+  - <<: (( merge on key ))
+cert_type: (( variables.cert.type ))
+`)
+
+			resolved := parseYAML(`
+---
+variables:
+  - key: password
+    type: secret
+  - key: cert
+    type: certificate
+cert_type: certificate
+`)
+
+			Expect(source).To(FlowAs(resolved, stub))
+		})
+
+		It("keeps the key after the first pass", func() {
+			source := parseYAML(`
+---
+variables:
+  - key: password
+    type: (( merge || "plain" ))
+    length: (( size ))
+  - <<: (( merge on key ))
+size: 16
+`)
+
+			resolved := parseYAML(`
+---
+variables:
+  - key: password
+    type: secret
+    length: 16
+  - key: cert
+    type: certificate
+size: 16
+`)
+
+			Expect(source).To(FlowAs(resolved, stub))
+		})
+	})
+
+	Describe("deleting nodes", func() {
+		It("removes map keys deleted by a stub", func() {
+			source := parseYAML(`
+---
+properties:
+  debug:
+    level: 3
+  port: 8080
+`)
+
+			stub := parseYAML(`
+---
+properties:
+  debug: (( delete ))
+`)
+
+			resolved := parseYAML(`
+---
+properties:
+  port: 8080
+`)
+
+			Expect(source).To(FlowAs(resolved, stub))
+		})
+
+		It("removes named list entries deleted by a stub", func() {
+			source := parseYAML(`
+---
+jobs:
+- name: api
+  instances: 2
+- name: worker
+  instances: 1
+`)
+
+			stub := parseYAML(`
+---
+jobs:
+- name: worker
+  <<: (( delete ))
+`)
+
+			resolved := parseYAML(`
+---
+jobs:
+- name: api
+  instances: 2
+`)
+
+			Expect(source).To(FlowAs(resolved, stub))
+		})
+
+		It("removes nodes the template deletes itself", func() {
+			source := parseYAML(`
+---
+debug: false
+properties:
+  level: '(( debug ? 3 : delete ))'
+  port: 8080
+`)
+
+			resolved := parseYAML(`
+---
+debug: false
+properties:
+  port: 8080
+`)
+
+			Expect(source).To(FlowAs(resolved))
+		})
+
+		It("does not delete nodes kept from the stubs", func() {
+			source := parseYAML(`
+---
+properties:
+  <<: (( merge none ))
+  debug: true
+`)
+
+			stub := parseYAML(`
+---
+properties:
+  debug: (( delete ))
+`)
+
+			resolved := parseYAML(`
+---
+properties:
+  debug: true
+`)
+
+			Expect(source).To(FlowAs(resolved, stub))
+		})
+	})
+
+	Describe("temporary nodes", func() {
+		It("removes temporary maps after they are referred to", func() {
+			source := parseYAML(`
+---
+meta:
+  <<: (( &temporary ))
+  domain: example.com
+  port: 8080
+uri: (( "https://" meta.domain ":" meta.port ))
+`)
+
+			resolved := parseYAML(`
+---
+uri: https://example.com:8080
+`)
+
+			Expect(source).To(FlowAs(resolved))
+		})
+
+		It("removes temporary values", func() {
+			source := parseYAML(`
+---
+domain: (( &temporary merge || "example.com" ))
+uri: (( "https://" domain ))
+`)
+
+			stub := parseYAML(`
+---
+domain: example.org
+`)
+
+			resolved := parseYAML(`
+---
+uri: https://example.org
+`)
+
+			Expect(source).To(FlowAs(resolved, stub))
+		})
+
+		It("removes temporary lists", func() {
+			source := parseYAML(`
+---
+zones:
+  - <<: (( &temporary ))
+  - z1
+  - z2
+first_zone: (( zones.[0] ))
+`)
+
+			resolved := parseYAML(`
+---
+first_zone: z1
+`)
+
+			Expect(source).To(FlowAs(resolved))
+		})
+
+		It("does not make the values taken from them temporary", func() {
+			source := parseYAML(`
+---
+meta:
+  <<: (( &temporary ))
+  properties:
+    port: 8080
+properties: (( meta.properties ))
+`)
+
+			resolved := parseYAML(`
+---
+properties:
+  port: 8080
+`)
+
+			Expect(source).To(FlowAs(resolved))
+		})
+
+		It("keeps them temporary when a stub overrides them", func() {
+			source := parseYAML(`
+---
+port: (( &temporary 8080 ))
+uri: (( "https://example.com:" port ))
+`)
+
+			stub := parseYAML(`
+---
+port: 9090
+`)
+
+			resolved := parseYAML(`
+---
+uri: https://example.com:9090
+`)
+
+			Expect(source).To(FlowAs(resolved, stub))
+		})
+	})
+
 	Describe("list splicing", func() {
 		It("merges one list into another", func() {
 			source := parseYAML(`
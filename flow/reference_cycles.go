@@ -0,0 +1,356 @@
+package flow
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/spiff/dynaml"
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+// ReferenceCycles reports the cycles found in a template, along with the
+// unresolved nodes that are not part of any of them.
+type ReferenceCycles struct {
+	Cycles     [][]string
+	Unresolved []UnresolvedNode
+}
+
+func (e ReferenceCycles) Error() string {
+	message := "reference cycles:"
+
+	for _, cycle := range e.Cycles {
+		message = fmt.Sprintf("%s\n\t%s", message, strings.Join(cycle, " -> "))
+	}
+
+	if len(e.Unresolved) > 0 {
+		message = fmt.Sprintf("%s\n%s", message, UnresolvedNodes{e.Unresolved}.Error())
+	}
+
+	return message
+}
+
+type scopeEntry struct {
+	path   []string
+	values map[string]yaml.Node
+}
+
+// dependencies maps the path of each dynaml node to the paths of the
+// dynaml nodes its references end up at.
+type dependencies map[string][]string
+
+// findReferenceCycles looks for dynaml nodes that (indirectly) refer to
+// themselves and returns one cycle per strongly connected component of the
+// dependency graph, starting and ending at its lexically smallest path.
+func findReferenceCycles(root yaml.Node) [][]string {
+	deps := dependencies{}
+	collectDependencies(root, root, []string{}, nil, deps, nil)
+
+	cycles := [][]string{}
+
+	for _, component := range stronglyConnectedComponents(deps) {
+		if len(component) == 1 && !contains(deps[component[0]], component[0]) {
+			continue
+		}
+
+		cycles = append(cycles, cycleThrough(component, deps))
+	}
+
+	sort.Sort(byFirstPath(cycles))
+
+	return cycles
+}
+
+// outsideCycles returns the unresolved nodes that are not part of any of the
+// cycles.
+func outsideCycles(root yaml.Node, unresolved []UnresolvedNode, cycles [][]string) []UnresolvedNode {
+	inCycle := map[string]bool{}
+	for _, cycle := range cycles {
+		for _, path := range cycle {
+			inCycle[path] = true
+		}
+	}
+
+	var rest []UnresolvedNode
+
+	for _, node := range unresolved {
+		if !inCycle[canonicalPath(root, node.Context)] {
+			rest = append(rest, node)
+		}
+	}
+
+	return rest
+}
+
+// canonicalPath names the steps of a path the way the paths of dynaml nodes
+// are named in cycles.
+func canonicalPath(root yaml.Node, path []string) string {
+	here := root
+	canonical := []string{}
+
+	for _, step := range path {
+		next, name, found := canonicalStep(here, step)
+		if !found {
+			return strings.Join(path, ".")
+		}
+
+		here = next
+		canonical = append(canonical, name)
+	}
+
+	return strings.Join(canonical, ".")
+}
+
+// collectDependencies fills in the dependencies of all dynaml nodes below
+// node, and, if paths is given, the path of each of them.
+func collectDependencies(root, node yaml.Node, path []string, scopes []scopeEntry, deps dependencies, paths map[string][]string) {
+	if node == nil {
+		return
+	}
+
+	switch val := node.Value().(type) {
+	case map[string]yaml.Node:
+		scopes = append(scopes[:len(scopes):len(scopes)], scopeEntry{path, val})
+
+		for key, sub := range val {
+			collectDependencies(root, sub, addContext(path, key), scopes, deps, paths)
+		}
+
+	case []yaml.Node:
+		for i, sub := range val {
+			collectDependencies(root, sub, addContext(path, stepName(i, sub, node.KeyName())), scopes, deps, paths)
+		}
+
+	case dynaml.Expression:
+		targets := []string{}
+
+		for _, ref := range dynaml.References(val) {
+			target, found := resolveDynamlNode(root, ref, scopes)
+			if found && !contains(targets, target) {
+				targets = append(targets, target)
+			}
+		}
+
+		sort.Strings(targets)
+
+		key := strings.Join(path, ".")
+
+		deps[key] = targets
+
+		if paths != nil {
+			paths[key] = path
+		}
+	}
+}
+
+// resolveDynamlNode follows a reference the way Environment does, and
+// returns the path of the dynaml node it runs into, if any.
+func resolveDynamlNode(root yaml.Node, ref []string, scopes []scopeEntry) (string, bool) {
+	var here yaml.Node
+	var path []string
+
+	if ref[0] == "" {
+		here = root
+		path = []string{}
+	} else {
+		for i := len(scopes); i > 0; i-- {
+			val := scopes[i-1].values[ref[0]]
+			if val != nil {
+				here = val
+				path = addContext(scopes[i-1].path, ref[0])
+				break
+			}
+		}
+
+		if here == nil {
+			return "", false
+		}
+	}
+
+	for _, step := range ref[1:] {
+		if _, ok := here.Value().(dynaml.Expression); ok {
+			break
+		}
+
+		var name string
+		var found bool
+
+		here, name, found = canonicalStep(here, step)
+		if !found {
+			return "", false
+		}
+
+		path = addContext(path, name)
+	}
+
+	if _, ok := here.Value().(dynaml.Expression); !ok {
+		return "", false
+	}
+
+	return strings.Join(path, "."), true
+}
+
+// canonicalStep follows a single step of a path, returning the name of the
+// step as used for the paths of dynaml nodes.
+func canonicalStep(here yaml.Node, step string) (yaml.Node, string, bool) {
+	switch val := here.Value().(type) {
+	case map[string]yaml.Node:
+		next, found := val[step]
+		return next, step, found && next != nil
+
+	case []yaml.Node:
+		for i, sub := range val {
+			name := stepName(i, sub, here.KeyName())
+			if name == step || fmt.Sprintf("[%d]", i) == step {
+				return sub, name, true
+			}
+		}
+	}
+
+	return nil, "", false
+}
+
+// stronglyConnectedComponents implements Tarjan's algorithm.
+func stronglyConnectedComponents(deps dependencies) [][]string {
+	index := 0
+	indices := map[string]int{}
+	lowlinks := map[string]int{}
+	onStack := map[string]bool{}
+	stack := []string{}
+	components := [][]string{}
+
+	var connect func(string)
+	connect = func(node string) {
+		indices[node] = index
+		lowlinks[node] = index
+		index++
+
+		stack = append(stack, node)
+		onStack[node] = true
+
+		for _, next := range deps[node] {
+			if _, visited := indices[next]; !visited {
+				connect(next)
+				if lowlinks[next] < lowlinks[node] {
+					lowlinks[node] = lowlinks[next]
+				}
+			} else if onStack[next] && indices[next] < lowlinks[node] {
+				lowlinks[node] = indices[next]
+			}
+		}
+
+		if lowlinks[node] != indices[node] {
+			return
+		}
+
+		component := []string{}
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+
+			component = append(component, top)
+
+			if top == node {
+				break
+			}
+		}
+
+		sort.Strings(component)
+		components = append(components, component)
+	}
+
+	nodes := make([]string, 0, len(deps))
+	for node := range deps {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	for _, node := range nodes {
+		if _, visited := indices[node]; !visited {
+			connect(node)
+		}
+	}
+
+	return components
+}
+
+// cycleThrough walks a component from its first member, visiting as many
+// members as it can before taking the shortest way back.
+func cycleThrough(component []string, deps dependencies) []string {
+	start := component[0]
+
+	cycle := []string{start}
+	visited := map[string]bool{start: true}
+
+	current := start
+	for {
+		next := ""
+		for _, candidate := range deps[current] {
+			if contains(component, candidate) && !visited[candidate] {
+				next = candidate
+				break
+			}
+		}
+
+		if next == "" {
+			break
+		}
+
+		cycle = append(cycle, next)
+		visited[next] = true
+		current = next
+	}
+
+	return append(cycle, shortestPath(current, start, component, deps)...)
+}
+
+// shortestPath returns the steps leading from one member of a component to
+// another (or back to itself), excluding the starting point.
+func shortestPath(from, to string, component []string, deps dependencies) []string {
+	previous := map[string]string{}
+	queue := []string{from}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		for _, next := range deps[node] {
+			if !contains(component, next) {
+				continue
+			}
+
+			if next == to {
+				path := []string{to}
+				for step := node; step != from; step = previous[step] {
+					path = append([]string{step}, path...)
+				}
+
+				return path
+			}
+
+			if _, seen := previous[next]; !seen && next != from {
+				previous[next] = node
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return nil
+}
+
+func contains(list []string, val string) bool {
+	for _, elem := range list {
+		if elem == val {
+			return true
+		}
+	}
+
+	return false
+}
+
+type byFirstPath [][]string
+
+func (c byFirstPath) Len() int           { return len(c) }
+func (c byFirstPath) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+func (c byFirstPath) Less(i, j int) bool { return c[i][0] < c[j][0] }
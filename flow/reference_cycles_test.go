@@ -0,0 +1,103 @@
+package flow
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry-incubator/spiff/dynaml"
+	"github.com/cloudfoundry-incubator/spiff/yaml"
+)
+
+var _ = Describe("Reporting reference cycles", func() {
+	It("formats a message listing the cycles", func() {
+		err := ReferenceCycles{
+			Cycles: [][]string{
+				{"jobs.api.instances", "meta.size", "jobs.api.instances"},
+				{"foo", "foo"},
+			},
+		}
+
+		Expect(err.Error()).To(Equal(
+			`reference cycles:
+	jobs.api.instances -> meta.size -> jobs.api.instances
+	foo -> foo`))
+	})
+
+	It("lists the unresolved nodes outside of the cycles after them", func() {
+		err := ReferenceCycles{
+			Cycles: [][]string{
+				{"foo", "foo"},
+			},
+			Unresolved: []UnresolvedNode{
+				{
+					Node:    yaml.NewNode(dynaml.ReferenceExpr{Path: []string{"bar"}}, "some.yml"),
+					Context: []string{"baz"},
+				},
+			},
+		}
+
+		Expect(err.Error()).To(Equal(
+			`reference cycles:
+	foo -> foo
+unresolved nodes:
+	(( bar ))	in some.yml	baz	()`))
+	})
+})
+
+var _ = Describe("Finding reference cycles", func() {
+	It("finds nodes referring to themselves", func() {
+		source := parseYAML(`
+---
+foo:
+  bar: (( bar ))
+`)
+
+		Expect(findReferenceCycles(flow(source, Environment{}, true))).To(Equal([][]string{
+			{"foo.bar", "foo.bar"},
+		}))
+	})
+
+	It("follows references through lists and expressions", func() {
+		source := parseYAML(`
+---
+meta:
+  size: (( jobs.api.instances + 1 ))
+jobs:
+- name: api
+  instances: (( meta.size ))
+- name: worker
+  instances: (( .meta.size ))
+`)
+
+		Expect(findReferenceCycles(flow(source, Environment{}, true))).To(Equal([][]string{
+			{"jobs.api.instances", "meta.size", "jobs.api.instances"},
+		}))
+	})
+
+	It("reports each strongly connected component once", func() {
+		source := parseYAML(`
+---
+a: (( b ))
+b: (( c || a ))
+c: (( a ))
+d: (( e ))
+e: (( d ))
+`)
+
+		Expect(findReferenceCycles(flow(source, Environment{}, true))).To(Equal([][]string{
+			{"a", "b", "c", "a"},
+			{"d", "e", "d"},
+		}))
+	})
+
+	It("ignores references that are merely unresolved", func() {
+		source := parseYAML(`
+---
+a: (( b ))
+b: (( c ))
+c: (( merge ))
+`)
+
+		Expect(findReferenceCycles(flow(source, Environment{}, true))).To(BeEmpty())
+	})
+})
@@ -27,10 +27,14 @@ func (e UnresolvedNodes) Error() string {
 			"%s\n\t(( %s ))\tin %s\t%s\t(%s)",
 			message,
 			node.Value(),
-			node.SourceName(),
+			node.Origin(),
 			strings.Join(node.Context, "."),
 			strings.Join(node.Path, "."),
 		)
+
+		if node.Issue() != "" {
+			message = fmt.Sprintf("%s\t%s", message, node.Issue())
+		}
 	}
 
 	return message
@@ -60,6 +64,9 @@ func findUnresolvedNodes(root yaml.Node, context ...string) (nodes []UnresolvedN
 			)
 		}
 
+	case dynaml.DeleteExpr:
+		// removed once the template is fully flowed
+
 	case dynaml.Expression:
 		var path []string
 		switch val := root.Value().(type) {
@@ -36,4 +36,25 @@ var _ = Describe("Reporting unresolved nodes", func() {
 	(( auto ))	in some-file.yml	foo.bar	(foo.bar)
 	(( merge ))	in some-other-file.yml	fizz.[2].buzz	(fizz.fizzbuzz.buzz)`))
 	})
+
+	It("includes the reason a node could not be resolved", func() {
+		err := UnresolvedNodes{
+			Nodes: []UnresolvedNode{
+				{
+					Node: yaml.IssueNode(
+						yaml.NewNode(
+							dynaml.ReferenceExpr{Path: []string{"foo", "bar"}},
+							"some-file.yml",
+						),
+						"foo.bar not found",
+					),
+					Context: []string{"fizz"},
+				},
+			},
+		}
+
+		Expect(err.Error()).To(Equal(
+			`unresolved nodes:
+	(( foo.bar ))	in some-file.yml	fizz	()	foo.bar not found`))
+	})
 })
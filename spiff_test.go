@@ -4,6 +4,8 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -53,5 +55,336 @@ foo: bar
 				Expect(merge.Out).To(Say(`foo: bar`))
 			})
 		})
+
+		Context("when given variables", func() {
+			var template *os.File
+			var varsFile *os.File
+
+			BeforeEach(func() {
+				var err error
+
+				template, err = ioutil.TempFile(os.TempDir(), "template.yml")
+				Expect(err).NotTo(HaveOccurred())
+				template.Write([]byte(`
+---
+director_uuid: (( vars.director_uuid ))
+name: (( vars.name ))
+properties:
+  deployment: (( .vars.name ))
+user: (( env("SPIFF_TEST_USER") ))
+vars:
+  name: from-template
+`))
+
+				varsFile, err = ioutil.TempFile(os.TempDir(), "vars.yml")
+				Expect(err).NotTo(HaveOccurred())
+				varsFile.Write([]byte(`
+---
+director_uuid: some-uuid
+name: from-file
+`))
+
+				command := exec.Command(
+					spiff, "merge",
+					"--vars-file", varsFile.Name(),
+					"--var", "name=from-flag",
+					template.Name(),
+				)
+				command.Env = append(os.Environ(), "SPIFF_TEST_USER=admin")
+
+				merge, err = Start(command, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				os.Remove(template.Name())
+				os.Remove(varsFile.Name())
+			})
+
+			It("makes them available to the template", func() {
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(merge.Out).To(Say(`director_uuid: some-uuid`))
+				Expect(merge.Out).To(Say(`name: from-flag`))
+				Expect(merge.Out).To(Say(`deployment: from-flag`))
+				Expect(merge.Out).To(Say(`user: admin`))
+				Expect(merge.Out).To(Say(`name: from-template`))
+			})
+		})
+
+		Context("when the template reads other files", func() {
+			var dir string
+
+			BeforeEach(func() {
+				var err error
+
+				dir, err = ioutil.TempDir(os.TempDir(), "spiff-read")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = os.Mkdir(filepath.Join(dir, "jobs"), 0755)
+				Expect(err).NotTo(HaveOccurred())
+
+				files := map[string]string{
+					"template.yml": `
+---
+deployment: some-deployment
+jobs: (( read("jobs/jobs.yml") ))
+`,
+					"jobs/jobs.yml": `
+---
+- name: (( deployment "-job" ))
+  properties: (( read("properties.json") ))
+`,
+					"jobs/properties.json": `{"port": 8080, "motd": "(( deployment ))"}`,
+				}
+
+				for name, contents := range files {
+					err = ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644)
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				merge, err = Start(exec.Command(spiff, "merge", filepath.Join(dir, "template.yml")), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(dir)
+			})
+
+			It("reads them relative to the file they are read from", func() {
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(merge.Out).To(Say(`- name: some-deployment-job`))
+				Expect(merge.Out).To(Say(`port: 8080`))
+			})
+
+			It("leaves dynaml in JSON files as it is", func() {
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(merge.Out).To(Say(`motd: .*\(\( deployment \)\)`))
+			})
+		})
+
+		Context("when running commands is enabled", func() {
+			var template *os.File
+
+			BeforeEach(func() {
+				var err error
+
+				template, err = ioutil.TempFile(os.TempDir(), "template.yml")
+				Expect(err).NotTo(HaveOccurred())
+				template.Write([]byte(`
+---
+release_version: (( exec("echo", "42") ))
+`))
+
+				merge, err = Start(exec.Command(spiff, "merge", "--enable-exec", template.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				os.Remove(template.Name())
+			})
+
+			It("includes the output of the commands", func() {
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(merge.Out).To(Say(`release_version: 42`))
+			})
+		})
+
+		Context("when given stubs on stdin", func() {
+			var template *os.File
+
+			BeforeEach(func() {
+				var err error
+
+				template, err = ioutil.TempFile(os.TempDir(), "template.yml")
+				Expect(err).NotTo(HaveOccurred())
+				template.Write([]byte(`
+---
+name: (( merge ))
+director_uuid: (( merge ))
+`))
+
+				command := exec.Command(spiff, "merge", template.Name(), "-")
+				command.Stdin = strings.NewReader(`
+---
+name: first
+director_uuid: some-uuid
+---
+name: second
+`)
+
+				merge, err = Start(command, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				os.Remove(template.Name())
+			})
+
+			It("merges each document as a stub", func() {
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(merge.Out).To(Say(`director_uuid: some-uuid`))
+				Expect(merge.Out).To(Say(`name: second`))
+			})
+		})
+
+		Context("when given the vars file on stdin", func() {
+			var template *os.File
+
+			BeforeEach(func() {
+				var err error
+
+				template, err = ioutil.TempFile(os.TempDir(), "template.yml")
+				Expect(err).NotTo(HaveOccurred())
+				template.Write([]byte(`
+---
+name: (( vars.name ))
+`))
+
+				command := exec.Command(spiff, "merge", "--vars-file", "-", template.Name())
+				command.Stdin = strings.NewReader(`
+---
+name: from-stdin
+`)
+
+				merge, err = Start(command, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				os.Remove(template.Name())
+			})
+
+			It("reads the variables from stdin", func() {
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(merge.Out).To(Say(`name: from-stdin`))
+			})
+		})
+
+		Context("when given a template with several documents", func() {
+			var template *os.File
+			var stub *os.File
+
+			BeforeEach(func() {
+				var err error
+
+				template, err = ioutil.TempFile(os.TempDir(), "template.yml")
+				Expect(err).NotTo(HaveOccurred())
+				template.Write([]byte(`
+---
+name: (( merge ))
+---
+name: (( merge ))
+kind: second
+`))
+
+				stub, err = ioutil.TempFile(os.TempDir(), "stub.yml")
+				Expect(err).NotTo(HaveOccurred())
+				stub.Write([]byte(`
+---
+name: from-stub
+`))
+			})
+
+			AfterEach(func() {
+				os.Remove(template.Name())
+				os.Remove(stub.Name())
+			})
+
+			It("merges each of them with --split", func() {
+				var err error
+				merge, err = Start(exec.Command(spiff, "merge", "--split", template.Name(), stub.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(merge.Out).To(Say(`---\nname: from-stub\n`))
+				Expect(merge.Out).To(Say(`---\nkind: second\nname: from-stub\n`))
+			})
+
+			It("fails without --split", func() {
+				var err error
+				merge, err = Start(exec.Command(spiff, "merge", template.Name(), stub.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(merge.Err).To(Say("use --split"))
+			})
+		})
+
+		Context("when given stdin more than once", func() {
+			BeforeEach(func() {
+				var err error
+				merge, err = Start(exec.Command(spiff, "merge", "-", "-"), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("says stdin can only be read once", func() {
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(merge.Err).To(Say("only one argument can be read from stdin"))
+			})
+		})
+
+		Context("when given stdin for both a vars file and a stub", func() {
+			BeforeEach(func() {
+				var err error
+				merge, err = Start(exec.Command(spiff, "merge", "--vars-file", "-", "foo.yml", "-"), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("says stdin can only be read once", func() {
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(merge.Err).To(Say("only one argument can be read from stdin"))
+			})
+		})
+
+		Context("when given a malformed variable", func() {
+			BeforeEach(func() {
+				var err error
+				merge, err = Start(exec.Command(spiff, "merge", "--var", "name", "foo.yml"), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("says how variables are given", func() {
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(merge.Err).To(Say("expected key=value"))
+			})
+		})
+	})
+
+	Describe("diff", func() {
+		var diff *Session
+
+		Context("when one of the files is stdin", func() {
+			var manifest *os.File
+
+			BeforeEach(func() {
+				var err error
+
+				manifest, err = ioutil.TempFile(os.TempDir(), "manifest.yml")
+				Expect(err).NotTo(HaveOccurred())
+				manifest.Write([]byte(`
+---
+instances: 1
+`))
+
+				command := exec.Command(spiff, "diff", manifest.Name(), "-")
+				command.Stdin = strings.NewReader(`
+---
+instances: 2
+`)
+
+				diff, err = Start(command, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				os.Remove(manifest.Name())
+			})
+
+			It("compares the file with stdin", func() {
+				Expect(diff.Wait()).To(Exit(0))
+				Expect(diff.Out).To(Say(`Difference in instances`))
+				Expect(diff.Out).To(Say(`<stdin>:3:12 has:`))
+			})
+		})
 	})
 })
@@ -0,0 +1,72 @@
+package yaml
+
+import (
+	"bytes"
+)
+
+// splitDocuments splits a stream at the --- and ... markers between its
+// documents, so that each can be decoded on its own. Each part is preceded by
+// as many empty lines as there are lines before it in the stream, so that the
+// lines reported for it are those of the stream. Parts holding nothing but
+// blank lines, comments and directives, like those before a leading ---, are
+// left out.
+func splitDocuments(source []byte) [][]byte {
+	docs := [][]byte{}
+
+	start, startLine := 0, 0
+
+	part := func(end int) {
+		if hasContent(source[start:end]) {
+			padded := bytes.Repeat([]byte("\n"), startLine)
+			docs = append(docs, append(padded, source[start:end]...))
+		}
+	}
+
+	for pos, line := 0, 0; pos < len(source); line++ {
+		end := bytes.IndexByte(source[pos:], '\n')
+		if end < 0 {
+			end = len(source)
+		} else {
+			end += pos + 1
+		}
+
+		text := source[pos:end]
+
+		if isMarker(text, "---") {
+			part(pos)
+			start, startLine = pos, line
+		} else if isMarker(text, "...") {
+			part(end)
+			start, startLine = end, line+1
+		}
+
+		pos = end
+	}
+
+	part(len(source))
+
+	return docs
+}
+
+// isMarker tells whether the line starts with the marker, followed by
+// whitespace or nothing.
+func isMarker(line []byte, marker string) bool {
+	if !bytes.HasPrefix(line, []byte(marker)) {
+		return false
+	}
+
+	rest := line[len(marker):]
+
+	return len(rest) == 0 || rest[0] == ' ' || rest[0] == '\t' || rest[0] == '\r' || rest[0] == '\n'
+}
+
+func hasContent(source []byte) bool {
+	for _, line := range bytes.Split(source, []byte("\n")) {
+		text := bytes.TrimSpace(line)
+		if len(text) > 0 && text[0] != '#' && text[0] != '%' {
+			return true
+		}
+	}
+
+	return false
+}
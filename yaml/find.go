@@ -8,16 +8,27 @@ import (
 var listIndex = regexp.MustCompile(`^\[(\d+)\]$`)
 
 func Find(root Node, path ...string) (Node, bool) {
+	return FindWithKeys(root, nil, path...)
+}
+
+// FindWithKeys is like Find, but steps through the lists along the path by
+// the given key names, where one is given, instead of by their own.
+func FindWithKeys(root Node, keyNames []string, path ...string) (Node, bool) {
 	here := root
 
-	for _, step := range path {
+	for i, step := range path {
 		if here == nil {
 			return nil, false
 		}
 
+		keyName := here.KeyName()
+		if i < len(keyNames) && keyNames[i] != "" {
+			keyName = keyNames[i]
+		}
+
 		var found bool
 
-		here, found = nextStep(step, here)
+		here, found = nextStep(step, here, keyName)
 		if !found {
 			return nil, false
 		}
@@ -46,21 +57,21 @@ func FindInt(root Node, path ...string) (int64, bool) {
 	return val, ok
 }
 
-func nextStep(step string, here Node) (Node, bool) {
+func nextStep(step string, here Node, keyName string) (Node, bool) {
 	found := false
 
 	switch v := here.Value().(type) {
 	case map[string]Node:
 		here, found = v[step]
 	case []Node:
-		here, found = stepThroughList(v, step)
+		here, found = stepThroughList(v, step, keyName)
 	default:
 	}
 
 	return here, found
 }
 
-func stepThroughList(here []Node, step string) (Node, bool) {
+func stepThroughList(here []Node, step string, keyName string) (Node, bool) {
 	match := listIndex.FindStringSubmatch(step)
 	if match != nil {
 		index, err := strconv.Atoi(match[1])
@@ -81,7 +92,7 @@ func stepThroughList(here []Node, step string) (Node, bool) {
 			continue
 		}
 
-		name, ok := FindString(sub, "name")
+		name, ok := FindString(sub, keyName)
 		if !ok {
 			continue
 		}
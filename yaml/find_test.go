@@ -33,7 +33,7 @@ foo:
 			It("accepts string keys to index maps", func() {
 				val, found := Find(tree, "foo", "bar", "baz")
 				Expect(found).To(BeTrue())
-				Expect(val).To(Equal(node("found")))
+				Expect(val.Value()).To(Equal("found"))
 			})
 		})
 
@@ -49,7 +49,40 @@ foo:
 			It("accepts [x] to index lists", func() {
 				val, found := Find(tree, "foo", "bar", "[1]", "fizz")
 				Expect(found).To(BeTrue())
-				Expect(val).To(Equal(node("right")))
+				Expect(val.Value()).To(Equal("right"))
+			})
+		})
+
+		Context("when the node tree contains lists of named maps", func() {
+			tree := parseYAML(`
+---
+foo:
+  - name: a
+    key: b
+    value: 1
+  - name: b
+    key: a
+    value: 2
+`)
+
+			It("steps through lists by name", func() {
+				val, found := Find(tree, "foo", "a", "value")
+				Expect(found).To(BeTrue())
+				Expect(val.Value()).To(Equal(int64(1)))
+			})
+
+			It("steps through lists by their key name", func() {
+				keyed := KeyNameNode(tree.Value().(map[string]Node)["foo"], "key")
+
+				val, found := Find(keyed, "a", "value")
+				Expect(found).To(BeTrue())
+				Expect(val.Value()).To(Equal(int64(2)))
+			})
+
+			It("steps through lists by the given key names", func() {
+				val, found := FindWithKeys(tree, []string{"", "key"}, "foo", "a", "value")
+				Expect(found).To(BeTrue())
+				Expect(val.Value()).To(Equal(int64(2)))
 			})
 		})
 
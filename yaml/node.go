@@ -2,6 +2,7 @@ package yaml
 
 import (
 	"reflect"
+	"strconv"
 
 	"github.com/cloudfoundry-incubator/candiedyaml"
 )
@@ -11,22 +12,136 @@ type Node interface {
 
 	Value() interface{}
 	SourceName() string
+	Origin() Origin
+	Issue() string
+	NoMerge() bool
+	KeyName() string
+	Temporary() bool
+	Literal() bool
 	EquivalentToNode(Node) bool
 }
 
+// DefaultKeyName is the field identifying the entries of lists, unless
+// another one is chosen with <<: (( merge on key )).
+const DefaultKeyName = "name"
+
 type AnnotatedNode struct {
-	value      interface{}
-	sourceName string
+	value     interface{}
+	origin    Origin
+	issue     string
+	noMerge   bool
+	keyName   string
+	temporary bool
+	literal   bool
 }
 
 func NewNode(value interface{}, sourcePath string) Node {
-	return AnnotatedNode{massageType(value), sourcePath}
+	return NewNodeWithOrigin(value, Origin{SourceName: sourcePath})
+}
+
+func NewNodeWithOrigin(value interface{}, origin Origin) Node {
+	return AnnotatedNode{value: massageType(value), origin: origin}
+}
+
+// SubstituteNode returns a node with the given value that originates from
+// the same place as the given node and is merged the same way.
+func SubstituteNode(value interface{}, node Node) Node {
+	annotated := annotate(node)
+	annotated.value = massageType(value)
+	annotated.issue = ""
+
+	return annotated
+}
+
+// IssueNode returns a copy of the node annotated with the reason why it
+// could not be resolved.
+func IssueNode(node Node, issue string) Node {
+	annotated := annotate(node)
+	annotated.issue = issue
+
+	return annotated
+}
+
+// NoMergeNode returns a copy of the node whose contents are not to be
+// overridden by stubs, as requested with <<: (( merge none )).
+func NoMergeNode(node Node) Node {
+	annotated := annotate(node)
+	annotated.noMerge = true
+
+	return annotated
+}
+
+// KeyNameNode returns a copy of the list node whose entries are identified
+// by the given field.
+func KeyNameNode(node Node, keyName string) Node {
+	annotated := annotate(node)
+	annotated.keyName = keyName
+
+	return annotated
+}
+
+// TemporaryNode returns a copy of the node that is, or is not, removed from
+// the output, as marked with (( &temporary )).
+func TemporaryNode(node Node, temporary bool) Node {
+	annotated := annotate(node)
+	annotated.temporary = temporary
+
+	return annotated
+}
+
+// LiteralNode returns a copy of the node, and of the nodes within it, whose
+// strings are taken as they are rather than as dynaml, as for data from
+// outside the templates.
+func LiteralNode(node Node) Node {
+	annotated := annotate(node)
+	annotated.literal = true
+
+	switch val := annotated.value.(type) {
+	case map[string]Node:
+		newMap := make(map[string]Node, len(val))
+		for key, sub := range val {
+			newMap[key] = LiteralNode(sub)
+		}
+
+		annotated.value = newMap
+
+	case []Node:
+		newList := make([]Node, len(val))
+		for i, sub := range val {
+			newList[i] = LiteralNode(sub)
+		}
+
+		annotated.value = newList
+	}
+
+	return annotated
+}
+
+func annotate(node Node) AnnotatedNode {
+	annotated, ok := node.(AnnotatedNode)
+	if ok {
+		return annotated
+	}
+
+	return AnnotatedNode{
+		value:     node.Value(),
+		origin:    node.Origin(),
+		issue:     node.Issue(),
+		noMerge:   node.NoMerge(),
+		keyName:   node.KeyName(),
+		temporary: node.Temporary(),
+		literal:   node.Literal(),
+	}
 }
 
 func massageType(value interface{}) interface{} {
-	switch value.(type) {
+	switch v := value.(type) {
 	case int, int8, int16, int32:
 		value = reflect.ValueOf(value).Int()
+	case float32:
+		// go through the shortest representation, so that e.g. 0.1 stays
+		// 0.1 instead of becoming 0.10000000149011612
+		value, _ = strconv.ParseFloat(strconv.FormatFloat(float64(v), 'g', -1, 32), 64)
 	}
 	return value
 }
@@ -36,7 +151,35 @@ func (n AnnotatedNode) Value() interface{} {
 }
 
 func (n AnnotatedNode) SourceName() string {
-	return n.sourceName
+	return n.origin.SourceName
+}
+
+func (n AnnotatedNode) Origin() Origin {
+	return n.origin
+}
+
+func (n AnnotatedNode) Issue() string {
+	return n.issue
+}
+
+func (n AnnotatedNode) NoMerge() bool {
+	return n.noMerge
+}
+
+func (n AnnotatedNode) KeyName() string {
+	if n.keyName == "" {
+		return DefaultKeyName
+	}
+
+	return n.keyName
+}
+
+func (n AnnotatedNode) Temporary() bool {
+	return n.temporary
+}
+
+func (n AnnotatedNode) Literal() bool {
+	return n.literal
 }
 
 func (n AnnotatedNode) MarshalYAML() (string, interface{}) {
@@ -25,6 +25,16 @@ var _ = Describe("Node", func() {
 		})
 	})
 
+	Describe("NewNode", func() {
+		It("normalizes ints to int64", func() {
+			Expect(NewNode(int32(42), "some/path").Value()).To(Equal(int64(42)))
+		})
+
+		It("normalizes float32 to float64, keeping its shortest representation", func() {
+			Expect(NewNode(float32(0.1), "some/path").Value()).To(Equal(0.1))
+		})
+	})
+
 	Describe("SourceName", func() {
 		It("returns the source name", func() {
 			subjectValue := "hello world"
@@ -35,6 +45,105 @@ var _ = Describe("Node", func() {
 		})
 	})
 
+	Describe("Origin", func() {
+		It("returns where the node was defined", func() {
+			origin := Origin{SourceName: "source/path", Line: 3, Column: 7}
+			subject := NewNodeWithOrigin("hello world", origin)
+			Expect(subject.Origin()).To(Equal(origin))
+			Expect(subject.SourceName()).To(Equal("source/path"))
+		})
+
+		It("formats as file, line and column", func() {
+			Expect(Origin{SourceName: "some.yml", Line: 3, Column: 7}.String()).To(Equal("some.yml:3:7"))
+			Expect(Origin{SourceName: "some.yml"}.String()).To(Equal("some.yml"))
+		})
+
+		It("includes the document of a stream", func() {
+			Expect(Origin{SourceName: "some.yml", Document: 2, Line: 3, Column: 7}.String()).To(Equal("some.yml#2:3:7"))
+		})
+	})
+
+	Describe("SubstituteNode", func() {
+		It("keeps the origin of the given node", func() {
+			origin := Origin{SourceName: "source/path", Line: 3, Column: 7}
+			subject := SubstituteNode(42, NewNodeWithOrigin("hello world", origin))
+			Expect(subject.Origin()).To(Equal(origin))
+			Expect(subject.Value()).To(Equal(int64(42)))
+		})
+	})
+
+	Describe("IssueNode", func() {
+		It("annotates a copy of the node with the issue", func() {
+			subject := NewNode("hello world", "source/path")
+			annotated := IssueNode(subject, "something went wrong")
+
+			Expect(subject.Issue()).To(Equal(""))
+			Expect(annotated.Issue()).To(Equal("something went wrong"))
+			Expect(annotated.Value()).To(Equal("hello world"))
+			Expect(annotated.SourceName()).To(Equal("source/path"))
+		})
+	})
+
+	Describe("NoMergeNode", func() {
+		It("marks a copy of the node as not to be merged", func() {
+			subject := NewNode("hello world", "source/path")
+			marked := NoMergeNode(subject)
+
+			Expect(subject.NoMerge()).To(BeFalse())
+			Expect(marked.NoMerge()).To(BeTrue())
+			Expect(marked.Value()).To(Equal("hello world"))
+		})
+
+		It("is kept by substitutes and issues", func() {
+			marked := NoMergeNode(NewNode("hello world", "source/path"))
+
+			Expect(SubstituteNode(42, marked).NoMerge()).To(BeTrue())
+			Expect(IssueNode(marked, "something went wrong").NoMerge()).To(BeTrue())
+		})
+	})
+
+	Describe("KeyNameNode", func() {
+		It("sets the key name of a copy of the node", func() {
+			subject := NewNode([]Node{}, "source/path")
+			keyed := KeyNameNode(subject, "key")
+
+			Expect(subject.KeyName()).To(Equal("name"))
+			Expect(keyed.KeyName()).To(Equal("key"))
+			Expect(SubstituteNode([]Node{}, keyed).KeyName()).To(Equal("key"))
+		})
+	})
+
+	Describe("TemporaryNode", func() {
+		It("marks a copy of the node as temporary or not", func() {
+			subject := NewNode("hello world", "source/path")
+			temporary := TemporaryNode(subject, true)
+
+			Expect(subject.Temporary()).To(BeFalse())
+			Expect(temporary.Temporary()).To(BeTrue())
+			Expect(SubstituteNode(42, temporary).Temporary()).To(BeTrue())
+			Expect(TemporaryNode(temporary, false).Temporary()).To(BeFalse())
+		})
+	})
+
+	Describe("LiteralNode", func() {
+		It("marks a copy of the node and the nodes within it as literal", func() {
+			entry := NewNode("(( foo ))", "source/path")
+			subject := NewNode(map[string]Node{
+				"list": NewNode([]Node{entry}, "source/path"),
+			}, "source/path")
+
+			literal := LiteralNode(subject)
+
+			Expect(subject.Literal()).To(BeFalse())
+			Expect(entry.Literal()).To(BeFalse())
+			Expect(literal.Literal()).To(BeTrue())
+
+			list := literal.Value().(map[string]Node)["list"]
+			Expect(list.Literal()).To(BeTrue())
+			Expect(list.Value().([]Node)[0].Literal()).To(BeTrue())
+		})
+	})
+
 	Describe("MarshalYAML", func() {
 		It("returns an empty string (tag) and the value", func() {
 			subjectValue := "hello world"
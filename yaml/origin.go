@@ -0,0 +1,31 @@
+package yaml
+
+import (
+	"fmt"
+)
+
+// Origin is the location a node was read from. Line and Column are 1-based;
+// they are zero for nodes that were not parsed from a document. Document is
+// the 1-based index of the document in a stream of several, and zero
+// otherwise. IncludedFrom is set for documents read from within another one.
+type Origin struct {
+	SourceName string
+	Document   int
+	Line       int
+	Column     int
+
+	IncludedFrom *Origin
+}
+
+func (o Origin) String() string {
+	source := o.SourceName
+	if o.Document > 0 {
+		source = fmt.Sprintf("%s#%d", source, o.Document)
+	}
+
+	if o.Line == 0 {
+		return source
+	}
+
+	return fmt.Sprintf("%s:%d:%d", source, o.Line, o.Column)
+}
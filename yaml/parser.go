@@ -1,11 +1,13 @@
 package yaml
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"reflect"
 
 	"github.com/cloudfoundry-incubator/candiedyaml"
+	yamlv3 "gopkg.in/yaml.v3"
 )
 
 type NonStringKeyError struct {
@@ -16,18 +18,125 @@ func (e NonStringKeyError) Error() string {
 	return fmt.Sprintf("map key must be a string: %#v", e.Key)
 }
 
+// MultipleDocumentsError is returned by Parse for streams holding more than
+// one document, which would otherwise be lost. ParseMulti parses all of them.
+type MultipleDocumentsError struct{}
+
+func (e MultipleDocumentsError) Error() string {
+	return "expected a single document, but found several separated by ---"
+}
+
+// PositionsError is returned if the lines and columns of a document cannot be
+// determined, rather than leaving its nodes without them.
+type PositionsError struct {
+	Err error
+}
+
+func (e PositionsError) Error() string {
+	return fmt.Sprintf("cannot determine source positions: %s", e.Err)
+}
+
 func Parse(sourceName string, source []byte) (Node, error) {
-	var parsed interface{}
+	return parse(Origin{SourceName: sourceName, Line: 1, Column: 1}, source)
+}
+
+// ParseIncluded parses a document read from within another one, recording
+// where it was included from in the origins of its nodes.
+func ParseIncluded(sourceName string, source []byte, includedFrom Origin) (Node, error) {
+	return parse(Origin{SourceName: sourceName, Line: 1, Column: 1, IncludedFrom: &includedFrom}, source)
+}
+
+// ParseMulti parses each document of a stream separated by ---. If there is
+// more than one, the origins of their nodes record which document they are
+// from.
+func ParseMulti(sourceName string, source []byte) ([]Node, error) {
+	parsed := []interface{}{}
+	trees := []*yamlv3.Node{}
+
+	for _, doc := range splitDocuments(source) {
+		val, tree, err := decode(doc)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed = append(parsed, val)
+		trees = append(trees, tree)
+	}
+
+	// a stream may end in ---, which is no document of its own
+	for len(parsed) > 0 && parsed[len(parsed)-1] == nil {
+		parsed = parsed[:len(parsed)-1]
+	}
+
+	docs := []Node{}
+
+	for i, doc := range parsed {
+		origin := Origin{SourceName: sourceName, Line: 1, Column: 1}
+		if len(parsed) > 1 {
+			origin.Document = i + 1
+		}
+
+		sanitized, err := sanitize(origin, doc, trees[i])
+		if err != nil {
+			return nil, err
+		}
 
-	err := candiedyaml.Unmarshal(source, &parsed)
+		docs = append(docs, sanitized)
+	}
+
+	return docs, nil
+}
+
+func parse(origin Origin, source []byte) (Node, error) {
+	docs := splitDocuments(source)
+	if len(docs) == 0 {
+		docs = [][]byte{source}
+	}
+
+	parsed, tree, err := decode(docs[0])
 	if err != nil {
 		return nil, err
 	}
 
-	return sanitize(sourceName, parsed)
+	// documents after the first one are fine as long as they are empty, as
+	// for a stream ending in ---
+	for _, doc := range docs[1:] {
+		later, _, err := decode(doc)
+		if err != nil {
+			return nil, err
+		}
+
+		if later != nil {
+			return nil, MultipleDocumentsError{}
+		}
+	}
+
+	return sanitize(origin, parsed, tree)
 }
 
-func sanitize(sourceName string, root interface{}) (Node, error) {
+// decode decodes a single document, along with the node tree its positions
+// are taken from.
+func decode(source []byte) (interface{}, *yamlv3.Node, error) {
+	var parsed interface{}
+
+	err := candiedyaml.NewDecoder(bytes.NewReader(source)).Decode(&parsed)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tree, err := positionTree(source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return parsed, tree, nil
+}
+
+// sanitize converts the decoded value into nodes, taking their positions from
+// the node tree of the value, if there is one.
+func sanitize(origin Origin, root interface{}, tree *yamlv3.Node) (Node, error) {
+	origin, tree = positioned(origin, tree)
+
 	switch rootVal := root.(type) {
 	case map[interface{}]interface{}:
 		sanitized := map[string]Node{}
@@ -38,7 +147,7 @@ func sanitize(sourceName string, root interface{}) (Node, error) {
 				return nil, NonStringKeyError{key}
 			}
 
-			sub, err := sanitize(sourceName, val)
+			sub, err := sanitize(origin, val, valuePosition(tree, str))
 			if err != nil {
 				return nil, err
 			}
@@ -46,13 +155,13 @@ func sanitize(sourceName string, root interface{}) (Node, error) {
 			sanitized[str] = sub
 		}
 
-		return AnnotatedNode{sanitized, sourceName}, nil
+		return NewNodeWithOrigin(sanitized, origin), nil
 
 	case []interface{}:
 		sanitized := []Node{}
 
-		for _, val := range rootVal {
-			sub, err := sanitize(sourceName, val)
+		for i, val := range rootVal {
+			sub, err := sanitize(origin, val, entryPosition(tree, i))
 			if err != nil {
 				return nil, err
 			}
@@ -60,10 +169,10 @@ func sanitize(sourceName string, root interface{}) (Node, error) {
 			sanitized = append(sanitized, sub)
 		}
 
-		return AnnotatedNode{sanitized, sourceName}, nil
+		return NewNodeWithOrigin(sanitized, origin), nil
 
 	case string, []byte, int64, float64, bool, nil:
-		return AnnotatedNode{rootVal, sourceName}, nil
+		return NewNodeWithOrigin(rootVal, origin), nil
 	}
 
 	return nil, errors.New(fmt.Sprintf("unknown type (%s) during sanitization: %#v\n", reflect.TypeOf(root).String(), root))
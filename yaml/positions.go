@@ -0,0 +1,75 @@
+package yaml
+
+import (
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// candiedyaml does not tell where the values it decodes were read from, so
+// their positions are taken from the node tree of a second parse of the
+// document with yaml.v3, which is walked along with the decoded values.
+
+// positionTree parses a single document into a yaml.v3 node tree, or returns
+// nil for an empty document. Both parsers descend from libyaml and accept the
+// same documents, so an error here is reported rather than leaving the nodes
+// without positions.
+func positionTree(source []byte) (*yamlv3.Node, error) {
+	var doc yamlv3.Node
+
+	err := yamlv3.Unmarshal(source, &doc)
+	if err != nil {
+		return nil, PositionsError{err}
+	}
+
+	if doc.Kind == 0 {
+		return nil, nil
+	}
+
+	if doc.Kind == yamlv3.DocumentNode && len(doc.Content) == 1 {
+		return doc.Content[0], nil
+	}
+
+	return &doc, nil
+}
+
+// positioned sets the line and column of the origin to the position of the
+// node, if it is known. It returns the node the children of the value are
+// found in, which for aliases is the anchored node.
+func positioned(origin Origin, node *yamlv3.Node) (Origin, *yamlv3.Node) {
+	if node == nil {
+		return origin, nil
+	}
+
+	origin.Line = node.Line
+	origin.Column = node.Column
+
+	if node.Kind == yamlv3.AliasNode {
+		return origin, node.Alias
+	}
+
+	return origin, node
+}
+
+// valuePosition is the node of the value of the key in a mapping node.
+func valuePosition(node *yamlv3.Node, key string) *yamlv3.Node {
+	if node == nil || node.Kind != yamlv3.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// entryPosition is the node of the entry with the given index in a sequence
+// node.
+func entryPosition(node *yamlv3.Node, index int) *yamlv3.Node {
+	if node == nil || node.Kind != yamlv3.SequenceNode || index >= len(node.Content) {
+		return nil
+	}
+
+	return node.Content[index]
+}